@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// groupableFields lists, per collection, the fields GET /admin/aggregate can
+// group by: low-cardinality values and references to parent records, not
+// names or ids that would give every record a group of its own.
+var groupableFields = map[string][]string{
+	"orgs":             {"status", "type", "parent.sourcedId"},
+	"users":            {"status", "role", "roles", "enabledUser", "grades", "orgs.sourcedId"},
+	"courses":          {"status", "grades", "subjects", "schoolYear.sourcedId"},
+	"classes":          {"status", "classType", "grades", "subjects", "periods", "course.sourcedId", "school.sourcedId", "terms.sourcedId"},
+	"enrollments":      {"status", "role", "primary", "school.sourcedId", "class.sourcedId"},
+	"academicSessions": {"status", "type", "schoolYear", "parent.sourcedId"},
+	"categories":       {"status", "weight"},
+	"lineItems":        {"status", "class.sourcedId", "category.sourcedId", "gradingPeriod.sourcedId"},
+	"results":          {"status", "scoreStatus", "lineItem.sourcedId", "student.sourcedId"},
+	"resources":        {"status", "roles", "importance", "vendorId"},
+}
+
+// getAggregate handles requests for record counts grouped by a field, e.g.
+// ?entity=users&groupBy=role gives {"student": 1000, "teacher": 250, ...}.
+// A record counts towards every value of an array field, so a user in two
+// orgs is counted under both, and not at all when the field is empty or
+// absent. Records are counted whatever their status.
+func (h *APIHandlers) getAggregate(w http.ResponseWriter, r *http.Request) {
+	entity := r.URL.Query().Get("entity")
+	groupBy := r.URL.Query().Get("groupBy")
+	fields, ok := groupableFields[entity]
+	if !ok {
+		collections := make([]string, 0, len(groupableFields))
+		for collection := range groupableFields {
+			collections = append(collections, collection)
+		}
+		slices.Sort(collections)
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid entity %q: must be one of %s", entity, strings.Join(collections, ", ")))
+		return
+	}
+	if !slices.Contains(fields, groupBy) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid groupBy %q for %s: must be one of %s", groupBy, entity, strings.Join(fields, ", ")))
+		return
+	}
+
+	ds := h.Store
+	var counts map[string]int
+	switch entity {
+	case "orgs":
+		counts = countBy(visibleOnly(ds, ds.Orgs), groupBy)
+	case "users":
+		counts = countBy(visibleOnly(ds, ds.Users), groupBy)
+	case "courses":
+		counts = countBy(visibleOnly(ds, ds.Courses), groupBy)
+	case "classes":
+		counts = countBy(visibleOnly(ds, ds.Classes), groupBy)
+	case "enrollments":
+		counts = countBy(visibleOnly(ds, ds.enrollments()), groupBy)
+	case "academicSessions":
+		counts = countBy(visibleOnly(ds, ds.AcademicSessions), groupBy)
+	case "categories":
+		counts = countBy(visibleOnly(ds, ds.Categories), groupBy)
+	case "lineItems":
+		counts = countBy(visibleOnly(ds, ds.LineItems), groupBy)
+	case "results":
+		counts = countBy(visibleOnly(ds, ds.Results), groupBy)
+	case "resources":
+		counts = countBy(visibleOnly(ds, ds.Resources), groupBy)
+	}
+	writeJSON(w, http.StatusOK, counts)
+}
+
+// countBy counts items by the values found at the dotted JSON path field,
+// which must resolve on T.
+func countBy[T any](items []T, field string) map[string]int {
+	var p filterPredicate
+	if _, err := resolvePath(reflect.TypeFor[T](), field, &p); err != nil {
+		panic("groupableFields: " + err.Error())
+	}
+	counts := make(map[string]int)
+	for _, item := range items {
+		for _, leaf := range collectLeaves(reflect.ValueOf(item), p.path) {
+			switch leaf.Kind() {
+			case reflect.String:
+				counts[leaf.String()]++
+			case reflect.Bool:
+				counts[strconv.FormatBool(leaf.Bool())]++
+			case reflect.Int:
+				counts[strconv.FormatInt(leaf.Int(), 10)]++
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Mock authentication.
+//
+// Every request needs an Authorization header. With no tokens configured any
+// value is accepted and grants every scope. Once MOCK_AUTH_TOKEN or
+// MOCK_AUTH_TOKENS is set, only "Bearer <token>" with a configured token is
+// accepted, and each OneRoster route additionally requires a scope:
+//
+//	401 Unauthorized  missing header or unknown token; re-authenticate
+//	403 Forbidden     known token without the route's scope; don't retry
+//
+// Scopes use the short OneRoster 1.1 names, e.g. roster.readonly, with the
+// https://purl.imsglobal.org/spec/or/v1p1/scope/ prefix left off.
+
+// knownScopes are the scopes a token can be granted.
+var knownScopes = []string{
+	"roster.readonly", "roster.createput", "roster.delete",
+	"gradebook.readonly", "gradebook.createput", "gradebook.delete",
+}
+
+// gradebookEntities are the collections guarded by gradebook scopes rather
+// than roster scopes.
+var gradebookEntities = []string{"lineItems", "results", "categories"}
+
+type scopesKey struct{}
+
+// authenticate returns middleware that rejects requests without a valid
+// token with 401 and records the token's scopes for requireScope. A nil
+// tokens map accepts any Authorization header with every scope.
+func authenticate(tokens map[string][]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Swagger UI assets, the raw spec and the health check don't
+			// need auth
+			if strings.HasPrefix(r.URL.Path, "/swagger/") || r.URL.Path == "/openapi.json" || r.URL.Path == "/health" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="OneRoster"`)
+				writeStatusInfo(w, http.StatusUnauthorized, "unauthorisedrequest", "Missing Authorization header")
+				return
+			}
+			scopes := knownScopes
+			if tokens != nil {
+				token, bearer := strings.CutPrefix(header, "Bearer ")
+				granted, known := tokens[token]
+				if !bearer || !known {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="OneRoster", error="invalid_token"`)
+					writeStatusInfo(w, http.StatusUnauthorized, "unauthorisedrequest", "Invalid or unknown bearer token")
+					return
+				}
+				scopes = granted
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), scopesKey{}, scopes)))
+		})
+	}
+}
+
+// requireScope returns middleware that rejects requests under basePath whose
+// token lacks the scope of the route with 403. Gradebook collections need a
+// gradebook scope and everything else a roster scope; reads and lookups need
+// .readonly, DELETE needs .delete and other writes .createput.
+func requireScope(basePath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := routeScope(r.Method, basePath, r.URL.Path)
+			granted, _ := r.Context().Value(scopesKey{}).([]string)
+			if !slices.Contains(granted, scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="OneRoster", error="insufficient_scope", scope="`+scope+`"`)
+				writeStatusInfo(w, http.StatusForbidden, "forbidden", "Token lacks the "+scope+" scope required by this request")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeScope returns the scope a request needs.
+func routeScope(method, basePath, path string) string {
+	area := "roster"
+	if slices.Contains(gradebookEntities, routeEntity(basePath, path)) {
+		area = "gradebook"
+	}
+	switch {
+	case method == http.MethodGet || method == http.MethodHead || strings.HasSuffix(strings.TrimSuffix(path, "/"), "/lookup"):
+		return area + ".readonly"
+	case method == http.MethodDelete:
+		return area + ".delete"
+	}
+	return area + ".createput"
+}
+
+// IntrospectionResponse is the RFC 7662 body returned by POST
+// /oauth/introspect. Tokens are configured statically rather than issued by
+// a token endpoint, so they have no client and never expire; client_id and
+// exp are left out for them.
+type IntrospectionResponse struct {
+	Active   bool   `json:"active"`
+	Scope    string `json:"scope,omitempty"`
+	ClientId string `json:"client_id,omitempty"`
+	Exp      int64  `json:"exp,omitempty"`
+}
+
+// introspect returns the handler for RFC 7662 token introspection. The
+// token to inspect is the form parameter "token"; the caller itself has
+// already been authenticated by authenticate. Unknown tokens are reported
+// as {"active": false}. A nil tokens map treats any token as active with
+// every scope, as authenticate does.
+func introspect(tokens map[string][]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.PostFormValue("token")
+		if token == "" {
+			writeError(w, http.StatusBadRequest, "Missing token form parameter")
+			return
+		}
+		scopes := knownScopes
+		if tokens != nil {
+			granted, known := tokens[token]
+			if !known {
+				writeJSON(w, http.StatusOK, IntrospectionResponse{Active: false})
+				return
+			}
+			scopes = granted
+		}
+		writeJSON(w, http.StatusOK, IntrospectionResponse{Active: true, Scope: strings.Join(scopes, " ")})
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxWriteBatch caps how many objects a single bulk write may carry.
+const maxWriteBatch = 1000
+
+// SchoolClassesRequest is the body accepted by PUT /schools/{id}/classes.
+// @Description A batch of classes to create or replace within one school.
+type SchoolClassesRequest struct {
+	Classes []Class `json:"classes"`
+}
+
+// WriteResult reports the outcome of one object in a bulk write.
+// @Description The outcome of writing one object of a bulk request.
+type WriteResult struct {
+	Index     int    `json:"index"` // position of the object in the request
+	SourcedId string `json:"sourcedId,omitempty"`
+	Status    string `json:"status"` // 'created', 'updated', 'failed'
+	Error     string `json:"error,omitempty"`
+}
+
+// putClassesForSchool handles bulk creation and replacement of a school's classes.
+// @Summary Create or replace classes for a school
+// @Description Upserts a batch of up to 1000 classes belonging to the school. Classes without a sourcedId are created
+// @Description with a generated one; classes whose sourcedId exists are replaced. Each class must reference an existing
+// @Description course and academic sessions and may not belong to another school. The response reports the outcome of
+// @Description every class in request order; invalid classes fail individually without affecting the rest.
+// @Tags Schools
+// @Accept json
+// @Produce json
+// @Param id path string true "SourcedId of the school"
+// @Param request body SchoolClassesRequest true "Classes to write"
+// @Success 200 {object} map[string][]WriteResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /schools/{id}/classes [put]
+func (h *APIHandlers) putClassesForSchool(w http.ResponseWriter, r *http.Request) {
+	var req SchoolClassesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if len(req.Classes) > maxWriteBatch {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many classes: at most %d are allowed per request", maxWriteBatch))
+		return
+	}
+
+	ds := h.Store
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	schoolId := chi.URLParam(r, "id")
+	if !slices.ContainsFunc(visibleOnly(ds, ds.Orgs), func(o Org) bool { return o.SourcedId == schoolId && o.Type == "school" }) {
+		writeError(w, http.StatusNotFound, "School not found")
+		return
+	}
+
+	report := make([]WriteResult, len(req.Classes))
+	for i, class := range req.Classes {
+		report[i] = ds.upsertClass(schoolId, class)
+		report[i].Index = i
+	}
+	writeJSON(w, http.StatusOK, map[string][]WriteResult{classEnvelope.plural: report})
+}
+
+// upsertClass validates class as a member of the given school and stores it,
+// replacing any class with the same SourcedId. The caller must hold ds.mu.
+func (ds *DataStore) upsertClass(schoolId string, class Class) WriteResult {
+	failed := func(format string, args ...any) WriteResult {
+		return WriteResult{SourcedId: class.SourcedId, Status: "failed", Error: fmt.Sprintf(format, args...)}
+	}
+
+	if class.Title == "" {
+		return failed("title is required")
+	}
+	if class.Status == "" {
+		class.Status = "active"
+	} else if !slices.Contains(statuses, class.status()) {
+		return failed("invalid status %q", class.Status)
+	}
+	if class.School.SourcedId != "" && class.School.SourcedId != schoolId {
+		return failed("class references school %s, not %s", class.School.SourcedId, schoolId)
+	}
+	class.School = GUIDRef{Href: "/schools/" + schoolId, SourcedId: schoolId, Type: "school"}
+	if !slices.ContainsFunc(ds.Courses, func(c Course) bool { return c.SourcedId == class.Course.SourcedId }) {
+		return failed("unknown course %q", class.Course.SourcedId)
+	}
+	class.Course = GUIDRef{Href: "/courses/" + class.Course.SourcedId, SourcedId: class.Course.SourcedId, Type: "course"}
+	if len(class.Terms) == 0 {
+		return failed("at least one term is required")
+	}
+	for t, term := range class.Terms {
+		i := slices.IndexFunc(ds.AcademicSessions, func(s AcademicSession) bool { return s.SourcedId == term.SourcedId })
+		if i < 0 {
+			return failed("unknown term %q", term.SourcedId)
+		}
+		class.Terms[t] = GUIDRef{Href: "/academicSessions/" + term.SourcedId, SourcedId: term.SourcedId, Type: "academicSession"}
+		if ds.AcademicSessions[i].Type == "term" {
+			class.Terms[t] = GUIDRef{Href: "/terms/" + term.SourcedId, SourcedId: term.SourcedId, Type: "term"}
+		}
+	}
+	class.DateLastModified = ds.now()
+
+	status := "created"
+	if class.SourcedId == "" {
+		class.SourcedId = uuid.New().String()
+	}
+	if i := slices.IndexFunc(ds.Classes, func(c Class) bool { return c.SourcedId == class.SourcedId }); i >= 0 {
+		if ds.Classes[i].School.SourcedId != schoolId {
+			return failed("class %s belongs to school %s", class.SourcedId, ds.Classes[i].School.SourcedId)
+		}
+		ds.Classes[i] = class
+		status = "updated"
+	} else {
+		ds.Classes = append(ds.Classes, class)
+	}
+	ds.markWritten(class.SourcedId)
+	return WriteResult{SourcedId: class.SourcedId, Status: status}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxChangeEvents caps how many change events are retained; older ones are
+// dropped first.
+const maxChangeEvents = 10000
+
+// Long polls of GET /admin/changes wait defaultChangesWait unless told
+// otherwise, and never longer than maxChangesWait, which stays below the
+// router's request timeout.
+const (
+	defaultChangesWait = 30 * time.Second
+	maxChangesWait     = 55 * time.Second
+)
+
+// ChangeEvent is one write recorded in the change log.
+type ChangeEvent struct {
+	Cursor    int64     `json:"cursor"` // the position of the event in the log
+	SourcedId string    `json:"sourcedId"`
+	At        time.Time `json:"at"`
+}
+
+// ChangesResponse is the body returned by GET /admin/changes. Cursor is the
+// value to pass as since in the next poll.
+type ChangesResponse struct {
+	Changes []ChangeEvent `json:"changes"`
+	Cursor  string        `json:"cursor"`
+}
+
+// changeLog records every write to the store in order, for long polls. Each
+// record closes and replaces wake, waking every poll waiting on it.
+type changeLog struct {
+	mu     sync.Mutex
+	events []ChangeEvent
+	last   int64
+	wake   chan struct{}
+}
+
+// record appends a change event for the record with the given SourcedId.
+func (c *changeLog) record(id string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last++
+	c.events = append(c.events, ChangeEvent{Cursor: c.last, SourcedId: id, At: at})
+	if len(c.events) > maxChangeEvents {
+		c.events = c.events[len(c.events)-maxChangeEvents:]
+	}
+	if c.wake != nil {
+		close(c.wake)
+		c.wake = nil
+	}
+}
+
+// since returns the retained events after cursor and the cursor of the
+// newest event. If there are none it also returns a channel closed by the
+// next record.
+func (c *changeLog) since(cursor int64) ([]ChangeEvent, int64, <-chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var events []ChangeEvent
+	for _, event := range c.events {
+		if event.Cursor > cursor {
+			events = append(events, event)
+		}
+	}
+	if len(events) > 0 {
+		return events, c.last, nil
+	}
+	if c.wake == nil {
+		c.wake = make(chan struct{})
+	}
+	return nil, c.last, c.wake
+}
+
+// getChanges handles long polls for writes. Without since it answers at once
+// with the current cursor. With since it answers as soon as a write past that
+// cursor is recorded, immediately if one already is, or with no changes and
+// the same cursor once wait (seconds, default 30, at most 55) has passed. A
+// wait beyond REQUEST_TIMEOUT ends in a 504 instead.
+// Only the newest maxChangeEvents events are retained, so a poll from too old
+// a cursor misses the dropped ones.
+func (h *APIHandlers) getChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	wait := defaultChangesWait
+	if query.Has("wait") {
+		seconds, err := strconv.Atoi(query.Get("wait"))
+		if err != nil || seconds < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid wait value: must be a non-negative number of seconds")
+			return
+		}
+		wait = min(time.Duration(seconds)*time.Second, maxChangesWait)
+	}
+	changes := &h.Store.changes
+	if !query.Has("since") {
+		_, last, _ := changes.since(0)
+		writeJSON(w, http.StatusOK, ChangesResponse{Changes: []ChangeEvent{}, Cursor: strconv.FormatInt(last, 10)})
+		return
+	}
+	cursor, err := strconv.ParseInt(query.Get("since"), 10, 64)
+	if err != nil || cursor < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid since value: must be a cursor returned by an earlier poll")
+		return
+	}
+
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	for {
+		events, last, wake := changes.since(cursor)
+		if len(events) > 0 {
+			writeJSON(w, http.StatusOK, ChangesResponse{Changes: events, Cursor: strconv.FormatInt(last, 10)})
+			return
+		}
+		select {
+		case <-wake:
+		case <-timeout.C:
+			writeJSON(w, http.StatusOK, ChangesResponse{Changes: []ChangeEvent{}, Cursor: strconv.FormatInt(max(cursor, last), 10)})
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// simClock is the store's source of the current time. By default it follows
+// the wall clock. In simulated mode it stands still at the time it was
+// started and only moves when advanced, so tests can step over delays such
+// as the write visibility delay without sleeping.
+type simClock struct {
+	mu        sync.Mutex
+	simulated bool
+	now       time.Time
+}
+
+// now returns the current time of the store's clock.
+func (ds *DataStore) now() time.Time {
+	ds.clock.mu.Lock()
+	defer ds.clock.mu.Unlock()
+	if ds.clock.simulated {
+		return ds.clock.now
+	}
+	return time.Now()
+}
+
+// SimulateClock switches the store to a simulated clock stopped at start.
+func (ds *DataStore) SimulateClock(start time.Time) {
+	ds.clock.mu.Lock()
+	defer ds.clock.mu.Unlock()
+	ds.clock.simulated = true
+	ds.clock.now = start
+}
+
+// advanceClock moves a simulated clock forward by d and returns the new time.
+// It reports false, leaving the clock alone, when the clock is not simulated.
+func (ds *DataStore) advanceClock(d time.Duration) (time.Time, bool) {
+	ds.clock.mu.Lock()
+	defer ds.clock.mu.Unlock()
+	if !ds.clock.simulated {
+		return time.Time{}, false
+	}
+	ds.clock.now = ds.clock.now.Add(d)
+	return ds.clock.now, true
+}
+
+// ClockAdvanceRequest is the body accepted by POST /admin/clock/advance.
+type ClockAdvanceRequest struct {
+	Duration string `json:"duration"` // a Go duration such as "90s" or "1h30m"
+}
+
+// postClockAdvance handles requests to move the simulated clock forward. It
+// answers 409 Conflict unless the server runs with MOCK_CLOCK=simulated.
+func (h *APIHandlers) postClockAdvance(w http.ResponseWriter, r *http.Request) {
+	var req ClockAdvanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	d, err := time.ParseDuration(req.Duration)
+	if err != nil || d < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid duration: must be a non-negative Go duration such as \"90s\" or \"1h30m\"")
+		return
+	}
+	now, ok := h.Store.advanceClock(d)
+	if !ok {
+		writeError(w, http.StatusConflict, "The clock is not simulated; start the server with MOCK_CLOCK=simulated")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]time.Time{"now": now})
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeCollection writes items under the given envelope key after applying
+// the query parameters shared by every collection endpoint: status,
+// showDeleted, modifiedSince and filter, then sort and orderBy, then limit and offset. The
+// X-Total-Count header carries the number of items before paging, and a Link
+// header points at the neighbouring pages when limit is given. limit=0 asks
+// for the count alone: the page is empty but X-Total-Count is accurate. With
+// envelope=false the items are written as a bare array instead of an object
+// keyed by key, and with idsOnly=true only their sourcedIds are written, under
+// "sourcedIds". after switches from offset to cursor paging (see itemsAfter).
+// Without sort, items keep the order they are given in, unless the request
+// went through unstableOrder. A request that went through emptyNotFound gets
+// a 404 instead of an empty collection. consistentSnapshot and snapshotToken
+// page through a frozen view instead of the live collection (see pages.go).
+// fields reduces each item written to the listed keys (see selectFields).
+// Endpoint-specific parameters are applied by the caller beforehand.
+func writeCollection[T entity](w http.ResponseWriter, r *http.Request, key string, items []T) {
+	query := r.URL.Query()
+	envelope := true
+	if query.Has("envelope") {
+		value, err := strconv.ParseBool(query.Get("envelope"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid envelope value: must be true or false")
+			return
+		}
+		envelope = value
+	}
+	idsOnly := false
+	if query.Has("idsOnly") {
+		value, err := strconv.ParseBool(query.Get("idsOnly"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid idsOnly value: must be true or false")
+			return
+		}
+		idsOnly = value
+	}
+	var fields []string
+	if query.Has("fields") {
+		var err error
+		if fields, err = selectFields[T](query.Get("fields")); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if query.Has("status") {
+		status := strings.ToLower(query.Get("status"))
+		if !slices.Contains(statuses, status) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid status value %q: must be one of %s", query.Get("status"), strings.Join(statuses, ", ")))
+			return
+		}
+		items = withStatus(items, status)
+	}
+	switch showDeleted := query.Get("showDeleted"); showDeleted {
+	case "", "true":
+	case "false":
+		items = slices.DeleteFunc(slices.Clone(items), func(item T) bool { return item.status() == "tobedeleted" })
+	case "only":
+		items = withStatus(items, "tobedeleted")
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid showDeleted value %q: must be true, false or only", showDeleted))
+		return
+	}
+	if query.Has("modifiedSince") {
+		since, err := parseFilterTime(query.Get("modifiedSince"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid modifiedSince value: must be an RFC 3339 timestamp or a date")
+			return
+		}
+		items = slices.DeleteFunc(slices.Clone(items), func(item T) bool { return item.modified().Before(since) })
+	}
+	items, err := applyFilter(query.Get("filter"), items)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if field := query.Get("sort"); field != "" {
+		items, err = sortItems(items, field, query.Get("orderBy"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else if unstable, _ := r.Context().Value(unstableOrderKey{}).(bool); unstable && !query.Has("after") {
+		items = slices.Clone(items)
+		rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	}
+	items, r, ok := pinnedItems(w, r, key, items)
+	if !ok {
+		return
+	}
+
+	total := len(items)
+	if notFound, _ := r.Context().Value(emptyNotFoundKey{}).(bool); notFound && total == 0 {
+		writeError(w, http.StatusNotFound, "No "+key+" found")
+		return
+	}
+	limit, offset, err := parsePaging(query, total)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if query.Has("after") {
+		if query.Has("offset") || query.Has("sort") {
+			writeError(w, http.StatusBadRequest, "after cannot be combined with offset or sort")
+			return
+		}
+		items, err = itemsAfter(items, query.Get("after"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if limit > 0 && limit < len(items) {
+			setCursorLink(w, r, items[limit-1].sourcedID())
+		}
+	} else if query.Has("limit") && limit > 0 {
+		setPageLinks(w, r, limit, offset, total)
+	}
+	end := min(offset+limit, len(items))
+	if offset < end {
+		items = items[offset:end]
+	} else {
+		// An empty page is written as [] rather than null.
+		items = []T{}
+	}
+	if idsOnly {
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.sourcedID()
+		}
+		writeEnvelope(w, envelope, "sourcedIds", ids)
+		return
+	}
+	if fields != nil {
+		writeEnvelope(w, envelope, key, projectFields(items, fields))
+		return
+	}
+	writeEnvelope(w, envelope, key, items)
+}
+
+// selectFields parses the fields parameter, a comma-separated list of the
+// JSON keys of T to return, e.g. sourcedId,familyName. Unknown keys are an
+// error listing the known ones.
+func selectFields[T any](value string) ([]string, error) {
+	var known []string
+	for _, field := range schemaFields(reflect.TypeFor[T]()) {
+		known = append(known, field.JSONKey)
+	}
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		if !slices.Contains(known, field) {
+			return nil, fmt.Errorf("invalid fields value: unknown field %q (must be among %s)", field, strings.Join(known, ", "))
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid fields value: must name at least one field")
+	}
+	return fields, nil
+}
+
+// projectFields returns items as JSON objects holding only the given keys.
+// A key the item omits stays absent.
+func projectFields[T any](items []T, fields []string) []map[string]json.RawMessage {
+	projected := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		data, _ := json.Marshal(item)
+		var all map[string]json.RawMessage
+		json.Unmarshal(data, &all)
+		projected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				projected[i][field] = value
+			}
+		}
+	}
+	return projected
+}
+
+// writeEnvelope writes items under key, or as a bare array without envelope.
+func writeEnvelope[T any](w http.ResponseWriter, envelope bool, key string, items []T) {
+	if !envelope {
+		writeJSON(w, http.StatusOK, items)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]T{key: items})
+}
+
+// withStatus returns the items whose status matches, ignoring case.
+func withStatus[T entity](items []T, status string) []T {
+	var matched []T
+	for _, item := range items {
+		if item.status() == status {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// parsePaging reads the limit and offset parameters. Without a limit every
+// item from offset onwards is returned; a limit of 0 returns none.
+func parsePaging(query map[string][]string, total int) (limit, offset int, err error) {
+	limit = total
+	if values, ok := query["limit"]; ok {
+		limit, err = strconv.Atoi(values[0])
+		if err != nil || limit < 0 {
+			return 0, 0, fmt.Errorf("invalid limit value: must be a non-negative integer")
+		}
+	}
+	if values, ok := query["offset"]; ok {
+		offset, err = strconv.Atoi(values[0])
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset value: must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+// setPageLinks sets the Link header with first, prev, next and last page URLs.
+func setPageLinks(w http.ResponseWriter, r *http.Request, limit, offset, total int) {
+	link := func(rel string, offset int) string {
+		query := r.URL.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf("<%s?%s>; rel=%q", r.URL.Path, query.Encode(), rel)
+	}
+	last := 0
+	if total > 0 {
+		last = (total - 1) / limit * limit
+	}
+	links := []string{link("first", 0)}
+	if offset > 0 {
+		links = append(links, link("prev", max(offset-limit, 0)))
+	}
+	if offset+limit < total {
+		links = append(links, link("next", offset+limit))
+	}
+	links = append(links, link("last", last))
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+// itemsAfter implements cursor paging, which unlike offset paging neither
+// skips nor repeats items when the collection changes between pages. Items
+// are ordered by sourcedId, and only those after the sourcedId encoded in
+// cursor are kept; an empty cursor starts at the beginning. The cursor of the
+// next page travels in the Link header set by setCursorLink.
+func itemsAfter[T entity](items []T, cursor string) ([]T, error) {
+	after, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid after cursor: use the one from the Link header of the previous page")
+	}
+	sorted := slices.DeleteFunc(slices.Clone(items), func(item T) bool { return cursor != "" && item.sourcedID() <= string(after) })
+	slices.SortFunc(sorted, func(a, b T) int { return strings.Compare(a.sourcedID(), b.sourcedID()) })
+	return sorted, nil
+}
+
+// setCursorLink sets the Link header to the next page of a cursor-paged
+// collection, which follows the item with the given sourcedId.
+func setCursorLink(w http.ResponseWriter, r *http.Request, last string) {
+	query := r.URL.Query()
+	query.Set("after", base64.RawURLEncoding.EncodeToString([]byte(last)))
+	w.Header().Set("Link", fmt.Sprintf("<%s?%s>; rel=\"next\"", r.URL.Path, query.Encode()))
+}
+
+// sortItems returns a sorted copy of items ordered by the field named by its
+// JSON key (dotted paths are allowed, as in filters). orderBy is "asc"
+// (default) or "desc". The sort is stable, so ties keep their store order.
+func sortItems[T any](items []T, field, orderBy string) ([]T, error) {
+	var p filterPredicate
+	leaf, err := resolvePath(reflect.TypeFor[T](), field, &p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sort field %w", err)
+	}
+	if leaf != timeType && (leaf.Kind() < reflect.Bool || leaf.Kind() > reflect.Float64) && leaf.Kind() != reflect.String {
+		return nil, fmt.Errorf("invalid sort field %q: field is not sortable", field)
+	}
+	direction := 1
+	switch orderBy {
+	case "", "asc":
+	case "desc":
+		direction = -1
+	default:
+		return nil, fmt.Errorf("invalid orderBy value: must be asc or desc")
+	}
+
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		return direction * compareLeaves(
+			collectLeaves(reflect.ValueOf(a), p.path),
+			collectLeaves(reflect.ValueOf(b), p.path))
+	})
+	return sorted, nil
+}
+
+// compareLeaves orders two items by the first value found at the sort path.
+// Items with no value there, such as a nil parent, sort first.
+func compareLeaves(a, b []reflect.Value) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return -1
+	case len(b) == 0:
+		return 1
+	}
+	x, y := a[0], b[0]
+	switch {
+	case x.Type() == timeType:
+		return x.Interface().(time.Time).Compare(y.Interface().(time.Time))
+	case x.Kind() == reflect.String:
+		return strings.Compare(x.String(), y.String())
+	case x.Kind() == reflect.Bool:
+		return compareFloat(boolToFloat(x.Bool()), boolToFloat(y.Bool()))
+	case x.CanInt():
+		return compareFloat(float64(x.Int()), float64(y.Int()))
+	case x.CanUint():
+		return compareFloat(float64(x.Uint()), float64(y.Uint()))
+	case x.CanFloat():
+		return compareFloat(x.Float(), y.Float())
+	}
+	return 0
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// TestFieldsOnRelationshipRoute checks that fields projects the records of
+// a relationship route as it does those of a top-level one, and rejects
+// fields the records don't have.
+func TestFieldsOnRelationshipRoute(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	path := "/classes/" + s.store.Classes[0].SourcedId + "/students"
+
+	rec := s.get(t, path+"?fields=sourcedId,familyName")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s?fields=sourcedId,familyName: %d %s", path, rec.Code, rec.Body)
+	}
+	students := decode[map[string][]map[string]json.RawMessage](t, rec)["users"]
+	if len(students) == 0 {
+		t.Fatalf("GET %s: no students", path)
+	}
+	for _, student := range students {
+		var keys []string
+		for key := range student {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		if !slices.Equal(keys, []string{"familyName", "sourcedId"}) {
+			t.Errorf("projected student has fields %v, want familyName and sourcedId", keys)
+		}
+	}
+
+	if rec := s.get(t, path+"?fields=sourcedId,shoeSize"); rec.Code != http.StatusBadRequest {
+		t.Errorf("GET %s?fields=sourcedId,shoeSize: %d %s, want 400", path, rec.Code, rec.Body)
+	}
+}
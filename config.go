@@ -0,0 +1,425 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Config holds every tunable of the mock server. LoadConfig fills it from the
+// environment; tests can start from DefaultConfig and override fields.
+type Config struct {
+	// Port is the TCP port the server listens on (MOCK_PORT, default 5100).
+	Port int
+	// BasePath is the prefix of every OneRoster route (MOCK_BASE_PATH,
+	// default /ims/oneroster/v1p1).
+	BasePath string
+
+	// DataFile, when set, names a JSON snapshot to serve instead of generated
+	// data (MOCK_DATA_FILE). Seed and the entity counts are then unused.
+	DataFile string
+	// Strict makes startup fail when ValidateStore finds violations in the
+	// data instead of only logging them (MOCK_STRICT).
+	Strict bool
+	// Profile names a preset of the settings below, if any (MOCK_PROFILE).
+	// The only one is golden, a tiny dataset with documented sourcedIds; see
+	// applyGoldenProfile.
+	Profile string
+	// Seed makes data generation reproducible (MOCK_SEED). Zero, the
+	// default, picks a random seed on every start.
+	Seed uint64
+	// Entity counts for data generation (MOCK_SCHOOLS, MOCK_STUDENTS,
+	// MOCK_TEACHERS, MOCK_COURSES, MOCK_CLASSES). Users are spread evenly over
+	// the schools, so there must be at least one teacher per school.
+	Schools  int
+	Students int
+	Teachers int
+	Courses  int
+	Classes  int
+
+	// CohortOverlap is the share, from 0 to 1, of each student's classes
+	// taken together with a fixed cohort of classmates (MOCK_COHORT_OVERLAP,
+	// default 1). The rest are taken with students of the grade dealt at
+	// random, so lower values give looser peer groups.
+	CohortOverlap float64
+	// LazyEnrollments defers building generated enrollments until a request
+	// reads them, a class's or user's at a time, to speed up startup with
+	// large counts (MOCK_LAZY_ENROLLMENTS). The enrollments are the same
+	// either way.
+	LazyEnrollments bool
+
+	// Locale picks the names of generated users (MOCK_LOCALE): en, the
+	// default, gives ASCII names, while es, ja, ru and ar give non-ASCII
+	// names from that language.
+	Locale string
+	// SessionTimeZone, when set, makes the start and end dates of generated
+	// academic sessions RFC 3339 timestamps in that fixed offset, such as
+	// 2025-09-01T00:00:00-05:00, instead of plain dates
+	// (MOCK_SESSION_TZ_OFFSET, Z or an offset such as -05:00).
+	SessionTimeZone *time.Location
+
+	// Latency is added before every API response (MOCK_LATENCY_MS).
+	Latency time.Duration
+	// RequestTimeout is how long the server gives a request before
+	// answering 504 Gateway Timeout (REQUEST_TIMEOUT, a Go duration such as
+	// 5s; the default is 60s). Simulated latency, TTFB delays and long polls
+	// are cut short by it.
+	RequestTimeout time.Duration
+	// TTFBDelay is added between sending the headers of an API response and
+	// the first byte of its body, which then follows at full speed
+	// (MOCK_TTFB_DELAY_MS).
+	TTFBDelay time.Duration
+	// ErrorRate is the fraction of API requests, from 0 to 1, that fail with
+	// a 500 (MOCK_ERROR_RATE).
+	ErrorRate float64
+	// RateLimit is the number of requests per second each client, told
+	// apart by its Authorization header, may make on average
+	// (MOCK_RATE_LIMIT). Zero, the default, disables rate limiting.
+	RateLimit float64
+	// RateBurst is how many requests a client may make at once before the
+	// rate limit applies (MOCK_RATE_BURST, default the rate rounded up).
+	RateBurst int
+	// AuthToken, when set, is a bearer token granted every scope
+	// (MOCK_AUTH_TOKEN).
+	AuthToken string
+	// TokenScopes maps further bearer tokens to the scopes they are granted
+	// (MOCK_AUTH_TOKENS, e.g. "ro:roster.readonly,gb:roster.readonly
+	// gradebook.readonly"). With neither AuthToken nor TokenScopes set any
+	// Authorization header is accepted.
+	TokenScopes map[string][]string
+	// WriteVisibilityDelay hides written records from reads for a while to
+	// emulate eventual consistency (MOCK_WRITE_VISIBILITY_DELAY_MS).
+	WriteVisibilityDelay time.Duration
+	// SimulatedClock stops the store's clock at startup so it only moves
+	// through POST /admin/clock/advance (MOCK_CLOCK=simulated; the default
+	// is real).
+	SimulatedClock bool
+	// CORSOrigins are the origins browsers may call the server from
+	// (CORS_ORIGINS, comma-separated). "*" allows any origin, in which case
+	// credentials are not allowed, as CORS requires.
+	CORSOrigins []string
+	// Chaos seeds the generated data with the inconsistencies real SIS data
+	// has, such as users sharing an email or username, names with emoji,
+	// users without an org, classes without a term or grades, or
+	// enrollments of missing users (MOCK_CHAOS).
+	Chaos bool
+	// RequireIfMatch makes updates of a single existing record fail with 428
+	// Precondition Required unless they carry an If-Match header
+	// (MOCK_REQUIRE_IF_MATCH). If-Match is honored either way.
+	RequireIfMatch bool
+	// UnstableOrder shuffles every collection that isn't explicitly sorted,
+	// differently on each request, to expose clients relying on implicit
+	// order across pages (MOCK_UNSTABLE_ORDER). By default collections keep
+	// their stable store order.
+	UnstableOrder bool
+	// SnakeCase renames the keys of every OneRoster response to snake_case,
+	// e.g. sourced_id, to emulate a non-conforming provider
+	// (MOCK_FIELD_NAMING=snake_case; the default is camelCase, as in the
+	// spec). Request bodies are still read as camelCase.
+	SnakeCase bool
+	// EmptyNotFound makes collections, top-level and relationship alike,
+	// answer 404 Not Found instead of 200 with an empty array when nothing
+	// matches (MOCK_EMPTY_COLLECTION=404; the default is 200, as in the
+	// spec).
+	EmptyNotFound bool
+	// TrailingSlash emulates a provider that serves OneRoster routes only
+	// with a trailing slash, e.g. /users/, answering 404 for /users
+	// (MOCK_TRAILING_SLASH). By default both forms are served.
+	TrailingSlash bool
+	// ExtraHeaders are set on every OneRoster response, to emulate a
+	// provider's proprietary headers (MOCK_EXTRA_HEADERS, e.g.
+	// "X-Provider-Version:2.3,X-Rostering-Source:sis").
+	ExtraHeaders http.Header
+	// DownEntities lists entity routes (e.g. "results", "lineItems") that
+	// answer 503 to emulate a partial provider outage (MOCK_DOWN_ENTITIES,
+	// comma-separated). Views of an entity's records, such as
+	// /classes/{id}/capacity, go down with the entity. Unknown names are a
+	// configuration error; see isCollection.
+	DownEntities []string
+	// DisabledEndpoints lists entity routes (e.g. "results") that answer 501
+	// Not Implemented, to emulate a provider that doesn't offer them, for
+	// testing capability detection (MOCK_DISABLED_ENDPOINTS,
+	// comma-separated). Entities are named, and views of their records
+	// disabled with them, as in DownEntities.
+	DisabledEndpoints []string
+
+	// Warnings describe settings that were ignored, such as malformed
+	// MOCK_EXTRA_HEADERS entries. Unlike errors they don't stop startup.
+	Warnings []string
+}
+
+// DefaultConfig returns the configuration used when no variable is set.
+func DefaultConfig() Config {
+	return Config{
+		Port:           5100,
+		BasePath:       "/ims/oneroster/v1p1",
+		Schools:        10,
+		Students:       1000,
+		Teachers:       250,
+		Courses:        50,
+		Classes:        500,
+		Locale:         "en",
+		CohortOverlap:  1,
+		RequestTimeout: 60 * time.Second,
+		CORSOrigins:    []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:5100"},
+	}
+}
+
+// LoadConfig reads the configuration from the environment on top of
+// DefaultConfig. Invalid values are reported as errors naming the variable.
+func LoadConfig() (Config, error) {
+	return loadConfig(os.Getenv)
+}
+
+// loadConfig is LoadConfig with the environment lookup injected.
+func loadConfig(getenv func(string) string) (Config, error) {
+	cfg := DefaultConfig()
+	var errs []string
+	intVar := func(name string, target *int, min int) {
+		value := getenv(name)
+		if value == "" {
+			return
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < min {
+			errs = append(errs, fmt.Sprintf("%s=%q: must be an integer of at least %d", name, value, min))
+			return
+		}
+		*target = n
+	}
+	boolVar := func(name string, target *bool) {
+		value := getenv(name)
+		if value == "" {
+			return
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s=%q: must be true or false", name, value))
+		}
+		*target = b
+	}
+	msVar := func(name string, target *time.Duration) {
+		ms := 0
+		intVar(name, &ms, 0)
+		*target = time.Duration(ms) * time.Millisecond
+	}
+
+	intVar("MOCK_PORT", &cfg.Port, 1)
+	if value := getenv("MOCK_BASE_PATH"); value != "" {
+		if !strings.HasPrefix(value, "/") || strings.HasSuffix(value, "/") {
+			errs = append(errs, fmt.Sprintf("MOCK_BASE_PATH=%q: must start with / and not end with /", value))
+		}
+		cfg.BasePath = value
+	}
+	cfg.DataFile = getenv("MOCK_DATA_FILE")
+	boolVar("MOCK_STRICT", &cfg.Strict)
+	switch value := getenv("MOCK_PROFILE"); value {
+	case "":
+	case "golden":
+		cfg.Profile = value
+		errs = append(errs, applyGoldenProfile(&cfg, getenv)...)
+	default:
+		errs = append(errs, fmt.Sprintf("MOCK_PROFILE=%q: must be golden", value))
+	}
+	if value := getenv("MOCK_SEED"); value != "" {
+		seed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("MOCK_SEED=%q: must be a non-negative integer", value))
+		}
+		cfg.Seed = seed
+	}
+	intVar("MOCK_SCHOOLS", &cfg.Schools, 1)
+	intVar("MOCK_STUDENTS", &cfg.Students, 0)
+	intVar("MOCK_TEACHERS", &cfg.Teachers, 1)
+	intVar("MOCK_COURSES", &cfg.Courses, 1)
+	intVar("MOCK_CLASSES", &cfg.Classes, 0)
+	if cfg.Teachers < cfg.Schools {
+		errs = append(errs, fmt.Sprintf("MOCK_TEACHERS=%d: must be at least MOCK_SCHOOLS (%d) so every school has a teacher", cfg.Teachers, cfg.Schools))
+	}
+	if value := getenv("MOCK_COHORT_OVERLAP"); value != "" {
+		overlap, err := strconv.ParseFloat(value, 64)
+		if err != nil || overlap < 0 || overlap > 1 {
+			errs = append(errs, fmt.Sprintf("MOCK_COHORT_OVERLAP=%q: must be a number from 0 to 1", value))
+		}
+		cfg.CohortOverlap = overlap
+	}
+	boolVar("MOCK_LAZY_ENROLLMENTS", &cfg.LazyEnrollments)
+	if value := getenv("MOCK_SESSION_TZ_OFFSET"); value != "" {
+		t, err := time.Parse("Z07:00", value)
+		_, offset := t.Zone()
+		if err != nil || offset < -12*60*60 || offset > 14*60*60 {
+			errs = append(errs, fmt.Sprintf("MOCK_SESSION_TZ_OFFSET=%q: must be Z or an offset from -12:00 to +14:00, such as -05:00", value))
+		}
+		cfg.SessionTimeZone = time.FixedZone(value, offset)
+	}
+	if value := getenv("MOCK_LOCALE"); value != "" {
+		if !slices.Contains(locales, value) {
+			errs = append(errs, fmt.Sprintf("MOCK_LOCALE=%q: must be one of %s", value, strings.Join(locales, ", ")))
+		}
+		cfg.Locale = value
+	}
+	if value := getenv("REQUEST_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			errs = append(errs, fmt.Sprintf("REQUEST_TIMEOUT=%q: must be a positive duration such as 30s", value))
+		}
+		cfg.RequestTimeout = timeout
+	}
+	msVar("MOCK_LATENCY_MS", &cfg.Latency)
+	msVar("MOCK_TTFB_DELAY_MS", &cfg.TTFBDelay)
+	if value := getenv("MOCK_ERROR_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			errs = append(errs, fmt.Sprintf("MOCK_ERROR_RATE=%q: must be a number from 0 to 1", value))
+		}
+		cfg.ErrorRate = rate
+	}
+	if value := getenv("MOCK_RATE_LIMIT"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			errs = append(errs, fmt.Sprintf("MOCK_RATE_LIMIT=%q: must be a non-negative number", value))
+		}
+		cfg.RateLimit = rate
+	}
+	cfg.RateBurst = max(int(math.Ceil(cfg.RateLimit)), 1)
+	intVar("MOCK_RATE_BURST", &cfg.RateBurst, 1)
+	cfg.AuthToken = getenv("MOCK_AUTH_TOKEN")
+	for _, entry := range strings.Split(getenv("MOCK_AUTH_TOKENS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		token, scopes, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || token == "" {
+			errs = append(errs, fmt.Sprintf("MOCK_AUTH_TOKENS entry %q: must be token:scope [scope...]", entry))
+			continue
+		}
+		if cfg.TokenScopes == nil {
+			cfg.TokenScopes = make(map[string][]string)
+		}
+		cfg.TokenScopes[token] = []string{}
+		for _, scope := range strings.Fields(scopes) {
+			if !slices.Contains(knownScopes, scope) {
+				errs = append(errs, fmt.Sprintf("MOCK_AUTH_TOKENS entry %q: unknown scope %q (known: %s)", entry, scope, strings.Join(knownScopes, ", ")))
+			}
+			cfg.TokenScopes[token] = append(cfg.TokenScopes[token], scope)
+		}
+	}
+	msVar("MOCK_WRITE_VISIBILITY_DELAY_MS", &cfg.WriteVisibilityDelay)
+	switch value := getenv("MOCK_CLOCK"); value {
+	case "", "real":
+	case "simulated":
+		cfg.SimulatedClock = true
+	default:
+		errs = append(errs, fmt.Sprintf("MOCK_CLOCK=%q: must be real or simulated", value))
+	}
+	if value := getenv("CORS_ORIGINS"); value != "" {
+		cfg.CORSOrigins = nil
+		for _, origin := range strings.Split(value, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.CORSOrigins = append(cfg.CORSOrigins, origin)
+			}
+		}
+		if slices.Contains(cfg.CORSOrigins, "*") && len(cfg.CORSOrigins) > 1 {
+			errs = append(errs, fmt.Sprintf("CORS_ORIGINS=%q: * cannot be combined with other origins", value))
+		}
+	}
+	switch value := getenv("MOCK_FIELD_NAMING"); value {
+	case "", "camelCase":
+	case "snake_case":
+		cfg.SnakeCase = true
+	default:
+		errs = append(errs, fmt.Sprintf("MOCK_FIELD_NAMING=%q: must be camelCase or snake_case", value))
+	}
+	switch value := getenv("MOCK_EMPTY_COLLECTION"); value {
+	case "", "200":
+	case "404":
+		cfg.EmptyNotFound = true
+	default:
+		errs = append(errs, fmt.Sprintf("MOCK_EMPTY_COLLECTION=%q: must be 200 or 404", value))
+	}
+	boolVar("MOCK_CHAOS", &cfg.Chaos)
+	boolVar("MOCK_TRAILING_SLASH", &cfg.TrailingSlash)
+	boolVar("MOCK_UNSTABLE_ORDER", &cfg.UnstableOrder)
+	boolVar("MOCK_REQUIRE_IF_MATCH", &cfg.RequireIfMatch)
+	for _, entry := range strings.Split(getenv("MOCK_EXTRA_HEADERS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || !validHeaderName(name) || !validHeaderValue(value) {
+			cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("MOCK_EXTRA_HEADERS entry %q: skipped, must be Name:value with a valid header name and value", entry))
+			continue
+		}
+		if cfg.ExtraHeaders == nil {
+			cfg.ExtraHeaders = make(http.Header)
+		}
+		cfg.ExtraHeaders.Add(name, value)
+	}
+	entitiesVar := func(name string, target *[]string) {
+		for _, entity := range strings.Split(getenv(name), ",") {
+			if entity = strings.TrimSpace(entity); entity == "" {
+				continue
+			}
+			if !isCollection(entity) {
+				errs = append(errs, fmt.Sprintf("%s entity %q: must be one of %s", name, entity, strings.Join(collections(), ", ")))
+				continue
+			}
+			*target = append(*target, entity)
+		}
+	}
+	entitiesVar("MOCK_DOWN_ENTITIES", &cfg.DownEntities)
+	entitiesVar("MOCK_DISABLED_ENDPOINTS", &cfg.DisabledEndpoints)
+
+	if len(errs) > 0 {
+		return Config{}, fmt.Errorf("invalid configuration: %s", strings.Join(errs, "; "))
+	}
+	return cfg, nil
+}
+
+// validHeaderName reports whether name is an HTTP header field name: a
+// non-empty token (RFC 9110, section 5.1).
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c > unicode.MaxASCII || !(unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("!#$%&'*+-.^_`|~", c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderValue reports whether value may be sent as a header field value:
+// visible ASCII, spaces and tabs, so no line breaks.
+func validHeaderValue(value string) bool {
+	for _, c := range value {
+		if c != '\t' && (c < ' ' || c > '~') {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenScopes returns the scopes of every accepted bearer token, or nil when
+// any Authorization header is accepted.
+func (cfg Config) tokenScopes() map[string][]string {
+	if cfg.AuthToken == "" && cfg.TokenScopes == nil {
+		return nil
+	}
+	tokens := make(map[string][]string, len(cfg.TokenScopes)+1)
+	for token, scopes := range cfg.TokenScopes {
+		tokens[token] = scopes
+	}
+	if cfg.AuthToken != "" {
+		tokens[cfg.AuthToken] = knownScopes
+	}
+	return tokens
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidHeaderValue(t *testing.T) {
+	for value, want := range map[string]bool{
+		"2.3":            true,
+		"sis export\t1":  true,
+		"":               true,
+		"a\r\nX-Evil: 1": false,
+		"del\x7f":        false,
+		"café":           false,
+	} {
+		if got := validHeaderValue(value); got != want {
+			t.Errorf("validHeaderValue(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+// TestExtraHeadersNonASCII checks that MOCK_EXTRA_HEADERS entries whose
+// value isn't ASCII are skipped with a warning.
+func TestExtraHeadersNonASCII(t *testing.T) {
+	getenv := func(key string) string {
+		if key == "MOCK_EXTRA_HEADERS" {
+			return "X-Provider:café,X-Version:2.3"
+		}
+		return ""
+	}
+	cfg, err := loadConfig(getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.ExtraHeaders.Get("X-Provider"); got != "" {
+		t.Errorf("X-Provider: %q, want it skipped", got)
+	}
+	if got := cfg.ExtraHeaders.Get("X-Version"); got != "2.3" {
+		t.Errorf("X-Version: %q, want 2.3", got)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("warnings %q, want one for X-Provider", cfg.Warnings)
+	}
+}
+
+// TestEntityLists checks that entity lists only accept the names of route
+// collections.
+func TestEntityLists(t *testing.T) {
+	downEntities := func(c Config) []string { return c.DownEntities }
+	disabledEndpoints := func(c Config) []string { return c.DisabledEndpoints }
+	for _, tc := range []struct {
+		name, value string
+		list        func(Config) []string
+		want        []string // nil if value is invalid
+	}{
+		{"MOCK_DOWN_ENTITIES", "users, lineItems,schools", downEntities, []string{"users", "lineItems", "schools"}},
+		{"MOCK_DOWN_ENTITIES", "user", downEntities, nil},
+		{"MOCK_DOWN_ENTITIES", "results,Users", downEntities, nil},
+		{"MOCK_DISABLED_ENDPOINTS", "results,gradingPeriods", disabledEndpoints, []string{"results", "gradingPeriods"}},
+		{"MOCK_DISABLED_ENDPOINTS", "capacity", disabledEndpoints, nil},
+		{"MOCK_DISABLED_ENDPOINTS", "lineitems", disabledEndpoints, nil},
+	} {
+		cfg, err := loadConfig(func(key string) string {
+			if key == tc.name {
+				return tc.value
+			}
+			return ""
+		})
+		switch {
+		case tc.want == nil && err == nil:
+			t.Errorf("%s=%q: accepted", tc.name, tc.value)
+		case tc.want != nil && err != nil:
+			t.Errorf("%s=%q: %v", tc.name, tc.value, err)
+		case tc.want != nil && !slices.Equal(tc.list(cfg), tc.want):
+			t.Errorf("%s=%q: parsed as %q, want %q", tc.name, tc.value, tc.list(cfg), tc.want)
+		}
+	}
+}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"fmt"
-	"github.com/google/uuid"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // BaseModel provides fields common to most OneRoster objects.
@@ -15,6 +21,41 @@ type BaseModel struct {
 	Metadata         any       `json:"metadata"`
 }
 
+// sourcedID returns the object's SourcedId. It is promoted to every model that
+// embeds BaseModel, which lets generic helpers look objects up by id.
+func (b BaseModel) sourcedID() string {
+	return b.SourcedId
+}
+
+// status returns the object's Status, normalized to lower case.
+func (b BaseModel) status() string {
+	return strings.ToLower(b.Status)
+}
+
+// metadata returns the object's Metadata extension block, which may be nil.
+func (b BaseModel) metadata() any {
+	return b.Metadata
+}
+
+func (b BaseModel) modified() time.Time {
+	return b.DateLastModified
+}
+
+func (b *BaseModel) base() *BaseModel {
+	return b
+}
+
+// entity is satisfied by every OneRoster model through its embedded BaseModel.
+type entity interface {
+	sourcedID() string
+	status() string
+	metadata() any
+	modified() time.Time
+}
+
+// statuses are the recognized values of BaseModel.Status.
+var statuses = []string{"active", "tobedeleted", "inactive"}
+
 // GUIDRef is a reference to another object in the system.
 // @Description A reference to another OneRoster object.
 type GUIDRef struct {
@@ -27,11 +68,14 @@ type GUIDRef struct {
 // @Description Represents an organization, such as a school or district.
 type Org struct {
 	BaseModel
-	Name       string    `json:"name"`
-	Type       string    `json:"type"` // e.g., 'school', 'district'
-	Identifier string    `json:"identifier"`
-	Parent     *GUIDRef  `json:"parent,omitempty"`
-	Children   []GUIDRef `json:"children,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"` // e.g., 'school', 'district'
+	Identifier string `json:"identifier"`
+	// Parent is written as null for a top-level org such as the district,
+	// so a missing parent field never means "has no parent": it would mean
+	// the parent was not included in the response.
+	Parent   *GUIDRef  `json:"parent" extensions:"x-nullable"`
+	Children []GUIDRef `json:"children,omitempty"`
 }
 
 // User represents a person, like a student or teacher.
@@ -43,10 +87,41 @@ type User struct {
 	EnabledUser bool      `json:"enabledUser"`
 	GivenName   string    `json:"givenName"`
 	FamilyName  string    `json:"familyName"`
-	Role        string    `json:"role"` // 'student', 'teacher'
+	Role        string    `json:"role"`  // primary role: 'student', 'teacher', 'administrator'
+	Roles       []string  `json:"roles"` // every role held, primary first
 	Identifier  string    `json:"identifier"`
 	Email       string    `json:"email"`
 	Orgs        []GUIDRef `json:"orgs"`
+	Grades      []string  `json:"grades,omitempty"` // students only: their grade level, e.g. "10"
+}
+
+// UserMetadata is the extension block generated for every user.
+// @Description Vendor extension fields carried in a user's metadata.
+type UserMetadata struct {
+	PreferredLanguage string `json:"preferredLanguage"`
+	Accommodations    bool   `json:"accommodations"`
+	// CollidesWith is set in chaos mode on a user deliberately given the
+	// email or username of another: it holds that other user's sourcedId.
+	CollidesWith string `json:"collidesWith,omitempty"`
+	// NameEdgeCase is set in chaos mode on a user deliberately given an
+	// awkward name: apostrophe, hyphen, emoji, long or whitespace.
+	NameEdgeCase string `json:"nameEdgeCase,omitempty"`
+	// Orgless is set in chaos mode on a user deliberately left with an
+	// empty orgs array.
+	Orgless bool `json:"orgless,omitempty"`
+	// TransferredFrom is set on a student who moved to another school
+	// partway through a term: it holds the sourcedId of the school they
+	// left, and TransferDate their first day at the new one.
+	TransferredFrom string `json:"transferredFrom,omitempty"`
+	TransferDate    string `json:"transferDate,omitempty"`
+}
+
+// userRoles are the roles a user may hold in OneRoster 1.1.
+var userRoles = []string{"administrator", "aide", "guardian", "parent", "proctor", "relative", "student", "teacher"}
+
+// hasRole reports whether the user holds role, as primary role or otherwise.
+func (u User) hasRole(role string) bool {
+	return u.Role == role || slices.Contains(u.Roles, role)
 }
 
 // Course represents a course catalog entry.
@@ -60,6 +135,17 @@ type Course struct {
 	Subjects     []string  `json:"subjects,omitempty"`
 	SubjectCodes []string  `json:"subjectCodes,omitempty"`
 	Resources    []GUIDRef `json:"resources,omitempty"`
+	// Prerequisites are the courses to complete before this one. They are
+	// an extension to OneRoster.
+	Prerequisites []GUIDRef `json:"prerequisites,omitempty"`
+}
+
+// CourseMetadata is the extension block of the courses chaos mode leaves
+// without some optional fields; other courses have none.
+// @Description Vendor extension fields carried in a course's metadata.
+type CourseMetadata struct {
+	// Omitted lists the optional fields deliberately left out, by JSON key.
+	Omitted []string `json:"omitted,omitempty"`
 }
 
 // Class represents a specific instance of a course.
@@ -69,7 +155,7 @@ type Class struct {
 	Title        string    `json:"title"`
 	ClassCode    string    `json:"classCode"`
 	ClassType    string    `json:"classType"` // 'homeroom', 'scheduled'
-	Location     string    `json:"location"`
+	Location     string    `json:"location,omitempty"`
 	Grades       []string  `json:"grades"`
 	Subjects     []string  `json:"subjects"`
 	Course       GUIDRef   `json:"course"`
@@ -80,30 +166,93 @@ type Class struct {
 	Resources    []GUIDRef `json:"resources,omitempty"`
 }
 
+// ClassMetadata is the extension block generated for every class.
+// @Description Vendor extension fields carried in a class's metadata.
+type ClassMetadata struct {
+	DeliveryMode     string `json:"deliveryMode"` // 'in-person', 'hybrid', 'online'
+	GradebookEnabled bool   `json:"gradebookEnabled"`
+	MaxEnrollment    int    `json:"maxEnrollment"` // student seats; 0 means uncapped
+	// Termless is set in chaos mode on a class deliberately left with an
+	// empty terms array.
+	Termless bool `json:"termless,omitempty"`
+	// Omitted lists, by JSON key, the optional fields chaos mode
+	// deliberately left out of the class.
+	Omitted []string `json:"omitted,omitempty"`
+}
+
 // Enrollment links a user to a class in a specific role.
 // @Description Represents the link between a user and a class for a specific role.
 type Enrollment struct {
 	BaseModel
-	User      GUIDRef  `json:"user"`
-	Class     GUIDRef  `json:"class"`
-	School    GUIDRef  `json:"school"`
-	Role      string   `json:"role"`
-	Primary   bool     `json:"primary"`
-	BeginDate string   `json:"beginDate"`
-	EndDate   string   `json:"endDate"`
+	User      GUIDRef `json:"user"`
+	Class     GUIDRef `json:"class"`
+	School    GUIDRef `json:"school"`
+	Role      string  `json:"role"`
+	Primary   bool    `json:"primary"`
+	BeginDate string  `json:"beginDate"`
+	EndDate   string  `json:"endDate"`
+}
+
+// EnrollmentMetadata is the extension block of the enrollments of
+// transferred students and of the orphaned enrollments generated in chaos
+// mode; other enrollments have none.
+// @Description Vendor extension fields carried in an enrollment's metadata.
+type EnrollmentMetadata struct {
+	// Orphaned names the reference, user or class, that points at a record
+	// that doesn't exist.
+	Orphaned string `json:"orphaned,omitempty"`
+	// Transfer is set on the enrollments of a transferred student in the
+	// term they moved: "out" on those at the school they left, which end on
+	// their last day there, and "in" on those at the new school.
+	Transfer string `json:"transfer,omitempty"`
 }
 
 // AcademicSession represents a time period like a term or semester.
 // @Description Represents a time period in the academic calendar, such as a term, semester, or grading period.
 type AcademicSession struct {
 	BaseModel
-	Title     string    `json:"title"`
-	StartDate string    `json:"startDate"`
-	EndDate   string    `json:"endDate"`
-	Type      string    `json:"type"` // 'gradingPeriod', 'semester', 'schoolYear', 'term'
-	Parent    *GUIDRef  `json:"parent,omitempty"`
-	Children  []GUIDRef `json:"children,omitempty"`
-	SchoolYear string   `json:"schoolYear"`
+	Title      string    `json:"title"`
+	StartDate  string    `json:"startDate"`
+	EndDate    string    `json:"endDate"`
+	Type       string    `json:"type"` // 'gradingPeriod', 'semester', 'schoolYear', 'term'
+	Parent     *GUIDRef  `json:"parent,omitempty"`
+	Children   []GUIDRef `json:"children,omitempty"`
+	SchoolYear string    `json:"schoolYear"`
+}
+
+// LineItem represents an assignment or other gradable activity in a class.
+// @Description Represents a gradable activity, such as an assignment or exam, within a class.
+type LineItem struct {
+	BaseModel
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	AssignDate     time.Time `json:"assignDate"`
+	DueDate        time.Time `json:"dueDate"`
+	Class          GUIDRef   `json:"class"`
+	Category       GUIDRef   `json:"category"`
+	GradingPeriod  GUIDRef   `json:"gradingPeriod"`
+	ResultValueMin float64   `json:"resultValueMin"`
+	ResultValueMax float64   `json:"resultValueMax"`
+}
+
+// Result represents a student's score on a line item.
+// @Description Represents the score a student achieved on a line item.
+type Result struct {
+	BaseModel
+	LineItem    GUIDRef  `json:"lineItem"`
+	Student     GUIDRef  `json:"student"`
+	ScoreStatus string   `json:"scoreStatus"` // one of scoreStatuses
+	Score       *float64 `json:"score"`       // null unless graded
+	ScoreDate   string   `json:"scoreDate"`
+	Comment     string   `json:"comment"`
+}
+
+// scoreStatuses are the recognized values of Result.ScoreStatus.
+var scoreStatuses = []string{"exempt", "fully graded", "not submitted", "partially graded", "submitted"}
+
+// graded reports whether a result with the given score status carries a score.
+func graded(scoreStatus string) bool {
+	return scoreStatus == "fully graded" || scoreStatus == "partially graded"
 }
 
 // Category represents a grading category for a class.
@@ -114,115 +263,993 @@ type Category struct {
 	Weight int    `json:"weight"`
 }
 
-// DataStore holds all our in-memory mock data.
+// Resource represents learning material, such as a textbook, that courses
+// and classes reference.
+// @Description Represents a learning resource referenced by courses and classes.
+type Resource struct {
+	BaseModel
+	Title            string   `json:"title"`
+	Roles            []string `json:"roles,omitempty"`      // the user roles it is meant for, from userRoles
+	Importance       string   `json:"importance,omitempty"` // one of resourceImportances
+	VendorResourceId string   `json:"vendorResourceId"`
+	VendorId         string   `json:"vendorId,omitempty"`
+	ApplicationId    string   `json:"applicationId,omitempty"`
+}
+
+// resourceImportances are the recognized values of Resource.Importance.
+var resourceImportances = []string{"primary", "secondary"}
+
+// DataStore holds all our in-memory mock data. Its JSON form is the snapshot
+// format of MOCK_DATA_FILE and GET /admin/export/json.
 type DataStore struct {
-	Orgs             []Org
-	Users            []User
-	Courses          []Course
-	Classes          []Class
-	Enrollments      []Enrollment
-	AcademicSessions []AcademicSession
-	Categories       []Category
-}
-
-// NewDataStore creates and populates a DataStore with a large volume of mock data.
-func NewDataStore() *DataStore {
-	ds := &DataStore{}
-
-	// --- Generate Orgs (Schools) ---
-	for i := 1; i <= 10; i++ {
-		schoolId := uuid.New().String()
+	// mu guards every collection below. Reads hold it shared for the whole
+	// request through readLocked; write handlers take it exclusively.
+	mu sync.RWMutex
+
+	Orgs             []Org             `json:"orgs"`
+	Users            []User            `json:"users"`
+	Courses          []Course          `json:"courses"`
+	Classes          []Class           `json:"classes"`
+	Enrollments      []Enrollment      `json:"enrollments"`
+	AcademicSessions []AcademicSession `json:"academicSessions"`
+	Categories       []Category        `json:"categories"`
+	Resources        []Resource        `json:"resources"`
+	LineItems        []LineItem        `json:"lineItems"`
+	Results          []Result          `json:"results"`
+
+	// resultsByStudent and resultsByClass index Results by student and by the
+	// class of the result's line item. They point into Results, so they must be
+	// rebuilt with rebuildResultIndexes whenever Results or LineItems change.
+	resultsByStudent map[string][]*Result
+	resultsByClass   map[string][]*Result
+
+	// pending holds the enrollments generation dealt but didn't build yet,
+	// with MOCK_LAZY_ENROLLMENTS; see lazy.go. It is nil otherwise.
+	pending *pendingEnrollments
+
+	// locale is the MOCK_LOCALE the names of generated users come from.
+	locale string
+	// seed is the seed the data was generated from, or zero for data loaded
+	// from a file.
+	seed uint64
+	// warnings describe shortfalls of generation, such as students left
+	// without enrollments because their grade has no classes.
+	warnings []string
+
+	visibility visibilityTracker
+	clock      simClock
+	snapshots  snapshotStore
+	changes    changeLog
+}
+
+// gradingPeriodWindows are the month-day ranges of the two grading periods
+// that split every generated fall term.
+var gradingPeriodWindows = []struct{ start, end string }{{"09-01", "10-25"}, {"10-26", "12-20"}}
+
+// GeneratedID returns the SourcedId NewDataStore gives the entity with the
+// given business key under seed. Ids are UUIDv5s of the key in a namespace
+// that is itself the UUIDv5 of "go-oneroster-mock:<seed>" in the URL
+// namespace, so an entity keeps its id however generation is reordered.
+// The keys are:
+//
+//	district:1
+//	school:<n>, student:<n>, teacher:<n>, course:<n>, class:<n>   n counts from 1 in generation order
+//	schoolYear:<year>, term:<year>, gradingPeriod:<year>:<n>      year is the fall year, e.g. 2025
+//	semester:<year>:<n>                                           n is 1 for fall, 2 for spring
+//	category:<title>                                              e.g. category:Homework
+//	resource:<courseId>:<kind>                                    kind is textbook or guide
+//	resource:room:<schoolId>:<room>                               room is the class location, e.g. Room 101
+//	enrollment:<classId>:<userId>
+//	lineItem:<classId>:<title>                                    e.g. lineItem:<classId>:Exam 2
+//	result:<lineItemId>:<studentId>
+//	orphan:<user|class>:<n>                                       the missing user or class of the orphaned copy
+//	                                                              of the n-th enrollment, in chaos mode
+//
+// Keys that join two entities use the generated ids of both.
+func GeneratedID(seed uint64, key string) string {
+	return uuid.NewSHA1(seedNamespace(seed), []byte(key)).String()
+}
+
+// seedNamespace returns the UUIDv5 namespace of the ids generated under seed.
+func seedNamespace(seed uint64) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("go-oneroster-mock:"+strconv.FormatUint(seed, 10)))
+}
+
+// NewDataStore creates and populates a DataStore with a large volume of mock
+// data, sized by the entity counts in cfg. A non-zero cfg.Seed makes the
+// generated data the same on every run; see GeneratedID for how ids derive
+// from it.
+func NewDataStore(cfg Config) *DataStore {
+	ds := &DataStore{locale: cfg.Locale}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	ds.seed = seed
+	rng := rand.New(rand.NewPCG(seed, seed))
+	namespace := seedNamespace(seed)
+	newID := func(format string, args ...any) string {
+		return uuid.NewSHA1(namespace, fmt.Appendf(nil, format, args...)).String()
+	}
+
+	// --- Generate Orgs (a District and its Schools) ---
+	districtId := newID("district:1")
+	districtRef := GUIDRef{Href: "/orgs/" + districtId, SourcedId: districtId, Type: "org"}
+	ds.Orgs = append(ds.Orgs, Org{
+		BaseModel:  BaseModel{SourcedId: districtId, Status: "active", DateLastModified: time.Now()},
+		Name:       "District #1",
+		Type:       "district",
+		Identifier: "DST001",
+	})
+	for i := 1; i <= cfg.Schools; i++ {
+		schoolId := newID("school:%d", i)
 		ds.Orgs = append(ds.Orgs, Org{
 			BaseModel:  BaseModel{SourcedId: schoolId, Status: "active", DateLastModified: time.Now()},
 			Name:       fmt.Sprintf("School #%d", i),
 			Type:       "school",
 			Identifier: fmt.Sprintf("SCH%03d", i),
+			Parent:     &districtRef,
 		})
+		ds.Orgs[0].Children = append(ds.Orgs[0].Children, GUIDRef{Href: "/orgs/" + schoolId, SourcedId: schoolId, Type: "org"})
 	}
+	schools := ds.Orgs[1:]
 
 	// --- Generate Users (Students & Teachers) ---
-	// 1000 Students
-	for i := 1; i <= 1000; i++ {
-		userId := uuid.New().String()
-		school := ds.Orgs[i%len(ds.Orgs)] // Assign student to a school
-		ds.Users = append(ds.Users, User{
-			BaseModel:   BaseModel{SourcedId: userId, Status: "active", DateLastModified: time.Now()},
-			Username:    fmt.Sprintf("student%d", i),
-			EnabledUser: true,
-			GivenName:   "Student",
-			FamilyName:  fmt.Sprintf("User%d", i),
-			Role:        "student",
-			Identifier:  fmt.Sprintf("STU%04d", i),
-			Email:       fmt.Sprintf("student%d@example.com", i),
-			Orgs:        []GUIDRef{{Href: "/orgs/" + school.SourcedId, SourcedId: school.SourcedId, Type: "org"}},
-		})
+	// Every user carries a UserMetadata block; one in eight students has
+	// accommodations.
+	// Students
+	for i := 1; i <= cfg.Students; i++ {
+		school := schools[i%len(schools)] // Assign student to a school
+		ds.Users = append(ds.Users, newStudent(newID("student:%d", i), i, school, gradeLevel(i, len(schools)), cfg.Locale))
 	}
-	// 250 Teachers
-	for i := 1; i <= 250; i++ {
-		userId := uuid.New().String()
-		school := ds.Orgs[i%len(ds.Orgs)] // Assign teacher to a school
+	// Teachers. One in twenty-five is primarily a school administrator who
+	// also teaches, and one in ten also works at the next school.
+	for i := 1; i <= cfg.Teachers; i++ {
+		userId := newID("teacher:%d", i)
+		school := schools[i%len(schools)] // Assign teacher to a school
+		given, family := personName(cfg.Locale, "teacher", i)
+		orgs := []GUIDRef{{Href: "/orgs/" + school.SourcedId, SourcedId: school.SourcedId, Type: "org"}}
+		if next := schools[(i+1)%len(schools)]; i%10 == 7 && next.SourcedId != school.SourcedId {
+			orgs = append(orgs, GUIDRef{Href: "/orgs/" + next.SourcedId, SourcedId: next.SourcedId, Type: "org"})
+		}
+		roles := []string{"teacher"}
+		if i%25 == 0 {
+			roles = []string{"administrator", "teacher"}
+		}
 		ds.Users = append(ds.Users, User{
-			BaseModel:   BaseModel{SourcedId: userId, Status: "active", DateLastModified: time.Now()},
+			BaseModel: BaseModel{SourcedId: userId, Status: "active", DateLastModified: time.Now(),
+				Metadata: &UserMetadata{PreferredLanguage: languages[i%len(languages)]}},
 			Username:    fmt.Sprintf("teacher%d", i),
 			EnabledUser: true,
-			GivenName:   "Teacher",
-			FamilyName:  fmt.Sprintf("User%d", i),
-			Role:        "teacher",
+			GivenName:   given,
+			FamilyName:  family,
+			Role:        roles[0],
+			Roles:       roles,
 			Identifier:  fmt.Sprintf("TCH%04d", i),
 			Email:       fmt.Sprintf("teacher%d@example.com", i),
-			Orgs:        []GUIDRef{{Href: "/orgs/" + school.SourcedId, SourcedId: school.SourcedId, Type: "org"}},
+			Orgs:        orgs,
 		})
 	}
 
-	// --- Generate Academic Sessions (Terms) ---
+	if cfg.Chaos {
+		ds.addIdentityCollisions(cfg.Students)
+		ds.addEdgeCaseNames(cfg.Students)
+	}
+
+	// --- Generate Academic Sessions (School Years > Semesters > Terms > Grading Periods) ---
+	// Each school year holds a fall and a spring semester. The fall semester
+	// holds one term, which is split into two grading periods. Every session
+	// lies within its parent, so their date ranges overlap all the way down;
+	// see GET /admin/sessions/overlaps.
+	var schoolYears []GUIDRef
+	termOfYear := make(map[string]GUIDRef)
 	for i := 1; i <= 4; i++ {
-		termId := uuid.New().String()
+		year := 2024 + i
+		yearId := newID("schoolYear:%d", year)
+		fallId := newID("semester:%d:1", year)
+		springId := newID("semester:%d:2", year)
+		termId := newID("term:%d", year)
+		yearRef := GUIDRef{Href: "/academicSessions/" + yearId, SourcedId: yearId, Type: "academicSession"}
+		fallRef := GUIDRef{Href: "/academicSessions/" + fallId, SourcedId: fallId, Type: "academicSession"}
+		springRef := GUIDRef{Href: "/academicSessions/" + springId, SourcedId: springId, Type: "academicSession"}
+		termRef := GUIDRef{Href: "/terms/" + termId, SourcedId: termId, Type: "term"}
+
+		semesters := []AcademicSession{{
+			BaseModel:  BaseModel{SourcedId: fallId, Status: "active", DateLastModified: time.Now()},
+			Title:      fmt.Sprintf("Fall Semester %d", year),
+			Type:       "semester",
+			StartDate:  fmt.Sprintf("%d-08-15", year),
+			EndDate:    fmt.Sprintf("%d-12-20", year),
+			Parent:     &yearRef,
+			Children:   []GUIDRef{termRef},
+			SchoolYear: strconv.Itoa(year),
+		}, {
+			BaseModel:  BaseModel{SourcedId: springId, Status: "active", DateLastModified: time.Now()},
+			Title:      fmt.Sprintf("Spring Semester %d", year+1),
+			Type:       "semester",
+			StartDate:  fmt.Sprintf("%d-01-06", year+1),
+			EndDate:    fmt.Sprintf("%d-06-30", year+1),
+			Parent:     &yearRef,
+			SchoolYear: strconv.Itoa(year),
+		}}
+		term := AcademicSession{
+			BaseModel:  BaseModel{SourcedId: termId, Status: "active", DateLastModified: time.Now()},
+			Title:      fmt.Sprintf("Fall Term %d", year),
+			Type:       "term",
+			StartDate:  fmt.Sprintf("%d-09-01", year),
+			EndDate:    fmt.Sprintf("%d-12-20", year),
+			Parent:     &fallRef,
+			SchoolYear: strconv.Itoa(year),
+		}
+		var periods []AcademicSession
+		for p, window := range gradingPeriodWindows {
+			periodId := newID("gradingPeriod:%d:%d", year, p+1)
+			periods = append(periods, AcademicSession{
+				BaseModel:  BaseModel{SourcedId: periodId, Status: "active", DateLastModified: time.Now()},
+				Title:      fmt.Sprintf("%s - Grading Period %d", term.Title, p+1),
+				Type:       "gradingPeriod",
+				StartDate:  fmt.Sprintf("%d-%s", year, window.start),
+				EndDate:    fmt.Sprintf("%d-%s", year, window.end),
+				Parent:     &termRef,
+				SchoolYear: term.SchoolYear,
+			})
+			term.Children = append(term.Children, GUIDRef{Href: "/gradingPeriods/" + periodId, SourcedId: periodId, Type: "gradingPeriod"})
+		}
+
 		ds.AcademicSessions = append(ds.AcademicSessions, AcademicSession{
-			BaseModel: BaseModel{SourcedId: termId, Status: "active", DateLastModified: time.Now()},
-			Title:     fmt.Sprintf("Fall Semester 202%d", i+4),
-			Type:      "term",
-			StartDate: fmt.Sprintf("202%d-09-01", i+4),
-			EndDate:   fmt.Sprintf("202%d-12-20", i+4),
-			SchoolYear: fmt.Sprintf("202%d", i+4),
+			BaseModel:  BaseModel{SourcedId: yearId, Status: "active", DateLastModified: time.Now()},
+			Title:      fmt.Sprintf("School Year %d-%d", year, year+1),
+			Type:       "schoolYear",
+			StartDate:  fmt.Sprintf("%d-08-15", year),
+			EndDate:    fmt.Sprintf("%d-06-30", year+1),
+			Children:   []GUIDRef{fallRef, springRef},
+			SchoolYear: term.SchoolYear,
 		})
+		ds.AcademicSessions = append(ds.AcademicSessions, semesters...)
+		ds.AcademicSessions = append(ds.AcademicSessions, term)
+		ds.AcademicSessions = append(ds.AcademicSessions, periods...)
+		schoolYears = append(schoolYears, yearRef)
+		termOfYear[yearId] = termRef
 	}
 
 	// --- Generate Courses ---
-	for i := 1; i <= 50; i++ {
-		courseId := uuid.New().String()
+	// Courses form prerequisite chains of five: each course requires the one
+	// before it, except the first of every five. Prerequisites always have a
+	// lower number, so the chains have no cycles.
+	for i := 1; i <= cfg.Courses; i++ {
+		courseId := newID("course:%d", i)
+		schoolYear := schoolYears[i%len(schoolYears)]
+		var prerequisites []GUIDRef
+		if i%5 != 1 {
+			prerequisiteId := newID("course:%d", i-1)
+			prerequisites = []GUIDRef{{Href: "/courses/" + prerequisiteId, SourcedId: prerequisiteId, Type: "course"}}
+		}
 		ds.Courses = append(ds.Courses, Course{
-			BaseModel:  BaseModel{SourcedId: courseId, Status: "active", DateLastModified: time.Now()},
-			Title:      fmt.Sprintf("Course %d", i),
-			CourseCode: fmt.Sprintf("CRS%03d", i),
-			Subjects:   []string{"General"},
+			BaseModel:     BaseModel{SourcedId: courseId, Status: "active", DateLastModified: time.Now()},
+			Title:         fmt.Sprintf("Course %d", i),
+			SchoolYear:    &schoolYear,
+			CourseCode:    fmt.Sprintf("CRS%03d", i),
+			Grades:        gradeLevels,
+			Subjects:      []string{"General"},
+			Prerequisites: prerequisites,
 		})
 	}
 
+	// --- Generate Resources ---
+	// Every course has a textbook for its students and teachers and a
+	// teacher's guide.
+	for i := range ds.Courses {
+		course := &ds.Courses[i]
+		for k, kind := range []struct {
+			key, title, importance string
+			roles                  []string
+		}{
+			{"textbook", "Textbook", "primary", []string{"student", "teacher"}},
+			{"guide", "Teacher's Guide", "secondary", []string{"teacher"}},
+		} {
+			resourceId := newID("resource:%s:%s", course.SourcedId, kind.key)
+			ds.Resources = append(ds.Resources, Resource{
+				BaseModel:        BaseModel{SourcedId: resourceId, Status: "active", DateLastModified: time.Now()},
+				Title:            course.Title + " " + kind.title,
+				Roles:            kind.roles,
+				Importance:       kind.importance,
+				VendorResourceId: fmt.Sprintf("%s-%d", course.CourseCode, k+1),
+				VendorId:         "mock-publisher",
+			})
+			course.Resources = append(course.Resources, GUIDRef{Href: "/resources/" + resourceId, SourcedId: resourceId, Type: "resource"})
+		}
+	}
+
 	// --- Generate Classes ---
-	for i := 1; i <= 500; i++ {
-		classId := uuid.New().String()
+	// Each class runs in the term of its course's school year, in one of
+	// seven periods of the day. Every tenth class is online and every fifth
+	// of the rest hybrid; one in four classes has the gradebook switched off.
+	// Classes that meet in person link the room resource of their location,
+	// made for each school the first time one of its classes uses the room.
+	rooms := make(map[string]GUIDRef)
+	for i := 1; i <= cfg.Classes; i++ {
+		classId := newID("class:%d", i)
 		course := ds.Courses[i%len(ds.Courses)]
-		school := ds.Orgs[i%len(ds.Orgs)]
-		term := ds.AcademicSessions[i%len(ds.AcademicSessions)]
+		school := schools[i%len(schools)]
+		term := termOfYear[course.SchoolYear.SourcedId]
+		location := classLocation(i)
+		var resources []GUIDRef
+		if deliveryMode(i) != "online" {
+			room, ok := rooms[school.SourcedId+":"+location]
+			if !ok {
+				resourceId := newID("resource:room:%s:%s", school.SourcedId, location)
+				ds.Resources = append(ds.Resources, Resource{
+					BaseModel:        BaseModel{SourcedId: resourceId, Status: "active", DateLastModified: time.Now()},
+					Title:            location,
+					Roles:            []string{"student", "teacher"},
+					Importance:       "secondary",
+					VendorResourceId: school.Identifier + "-" + strings.TrimPrefix(location, "Room "),
+					VendorId:         "mock-facilities",
+				})
+				room = GUIDRef{Href: "/resources/" + resourceId, SourcedId: resourceId, Type: "resource"}
+				rooms[school.SourcedId+":"+location] = room
+			}
+			resources = []GUIDRef{room}
+		}
 		ds.Classes = append(ds.Classes, Class{
-			BaseModel: BaseModel{SourcedId: classId, Status: "active", DateLastModified: time.Now()},
+			BaseModel: BaseModel{SourcedId: classId, Status: "active", DateLastModified: time.Now(),
+				Metadata: &ClassMetadata{DeliveryMode: deliveryMode(i), GradebookEnabled: i%4 != 0}},
 			Title:     course.Title,
 			ClassCode: fmt.Sprintf("%s-S%d", course.CourseCode, i),
 			ClassType: "scheduled",
 			Course:    GUIDRef{Href: "/courses/" + course.SourcedId, SourcedId: course.SourcedId, Type: "course"},
 			School:    GUIDRef{Href: "/schools/" + school.SourcedId, SourcedId: school.SourcedId, Type: "school"},
-			Terms:     []GUIDRef{{Href: "/terms/" + term.SourcedId, SourcedId: term.SourcedId, Type: "term"}},
-			Grades:    []string{"10"},
+			Terms:     []GUIDRef{term},
+			Location:  location,
+			Grades:    []string{gradeLevel(i, len(schools))},
 			Subjects:  []string{"General"},
+			Periods:   []string{strconv.Itoa(1 + i%7)},
+			Resources: resources,
 		})
 	}
 
+	sessions := make(map[string]AcademicSession, len(ds.AcademicSessions))
+	for _, session := range ds.AcademicSessions {
+		sessions[session.SourcedId] = session
+	}
+
+	// --- Generate Enrollments ---
+	// Every class gets one primary teacher and students of its grade from its
+	// school: each school's students of a grade are split into groups of
+	// about ten, and its classes of the grade take turns among the groups, so
+	// with the default counts every class has twelve or thirteen students and
+	// every student attends about six classes at their school. In the share
+	// cfg.CohortOverlap of classes the groups are fixed cohorts, whose
+	// students take those classes together; in the rest every student is
+	// dealt to a group at random. One class in six is co-taught by a second,
+	// non-primary teacher.
+	studentsBySchool := make(map[string][]User)
+	studentsByGrade := make(map[[2]string][]User)
+	teachersBySchool := make(map[string][]User)
+	for _, user := range ds.Users {
+		if user.hasRole("student") {
+			school := user.Orgs[0].SourcedId
+			studentsBySchool[school] = append(studentsBySchool[school], user)
+			key := [2]string{school, user.Grades[0]}
+			studentsByGrade[key] = append(studentsByGrade[key], user)
+		}
+		if user.hasRole("teacher") {
+			// Teachers of several schools may teach at any of them.
+			for _, org := range user.Orgs {
+				teachersBySchool[org.SourcedId] = append(teachersBySchool[org.SourcedId], user)
+			}
+		}
+	}
+	// Users are dealt to seats here and the enrollment records built from
+	// the seats once generation is done, or on demand; see lazy.go.
+	pending := &pendingEnrollments{namespace: namespace}
+	ds.pending = pending
+	for _, class := range ds.Classes {
+		term := sessions[class.Terms[0].SourcedId]
+		begin, _ := time.Parse(time.DateOnly, term.StartDate)
+		end, _ := time.Parse(time.DateOnly, term.EndDate)
+		pending.classes = append(pending.classes, pendingClass{id: class.SourcedId, school: class.School, begin: begin, end: end})
+	}
+	classesPerSchool := make(map[string]int)
+	classesPerGrade := make(map[[2]string]int)
+	for c, class := range ds.Classes {
+		school := class.School.SourcedId
+		k := classesPerSchool[school]
+		classesPerSchool[school]++
+		teachers := teachersBySchool[school]
+		pending.add(c, teachers[k%len(teachers)].SourcedId, true, true)
+		if k%6 == 3 && len(teachers) > 1 {
+			pending.add(c, teachers[(k+1)%len(teachers)].SourcedId, true, false)
+		}
+		key := [2]string{school, class.Grades[0]}
+		g := classesPerGrade[key]
+		classesPerGrade[key]++
+		students := studentsByGrade[key]
+		groups := max(len(students)/10, 1)
+		cohort := float64(g%10) < cfg.CohortOverlap*10
+		for j, student := range students {
+			group := j % groups
+			if !cohort {
+				group = rng.IntN(groups)
+			}
+			if group == g%groups {
+				pending.add(c, student.SourcedId, false, false)
+			}
+		}
+	}
+	// Enrollment history: one student in five also took a class of their
+	// grade in every other term their school offers, so their enrollments
+	// span several terms.
+	classesByTerm := make(map[[3]string][]int)
+	termOfClass := make(map[string]string, len(ds.Classes))
+	for c, class := range ds.Classes {
+		key := [3]string{class.School.SourcedId, class.Terms[0].SourcedId, class.Grades[0]}
+		termOfClass[class.SourcedId] = key[1]
+		classesByTerm[key] = append(classesByTerm[key], c)
+	}
+	enrolledTerms := make(map[string]map[string]bool)
+	for _, seat := range pending.seats {
+		if enrolledTerms[seat.user] == nil {
+			enrolledTerms[seat.user] = make(map[string]bool)
+		}
+		enrolledTerms[seat.user][termOfClass[seat.classId]] = true
+	}
+	for _, school := range schools {
+		for j, student := range studentsBySchool[school.SourcedId] {
+			if j%5 != 0 {
+				continue
+			}
+			for _, schoolYear := range schoolYears {
+				term := termOfYear[schoolYear.SourcedId].SourcedId
+				classes := classesByTerm[[3]string{school.SourcedId, term, student.Grades[0]}]
+				if len(classes) == 0 || enrolledTerms[student.SourcedId][term] {
+					continue
+				}
+				pending.add(classes[j%len(classes)], student.SourcedId, false, false)
+			}
+		}
+	}
+	ds.addTransfers(cfg.Students, schools, termOfClass, classesByTerm)
+	for _, school := range schools {
+		for _, grade := range gradeLevels {
+			key := [2]string{school.SourcedId, grade}
+			if n := len(studentsByGrade[key]); n > 0 && classesPerGrade[key] == 0 {
+				ds.warnings = append(ds.warnings, fmt.Sprintf("%s has %d students in grade %s but no class of that grade, so they have no enrollments", school.Name, n, grade))
+			}
+		}
+	}
+	if len(pending.seats) == 0 {
+		ds.warnings = append(ds.warnings, "No enrollments were generated")
+	}
+
+	// --- Set Enrollment Caps ---
+	// Caps leave a few seats free, except in one class in seven, which is
+	// enrolled two students beyond its cap.
+	studentCount := make(map[string]int)
+	for _, seat := range pending.seats {
+		if !seat.teacher {
+			studentCount[seat.classId]++
+		}
+	}
+	for i, class := range ds.Classes {
+		metadata := class.Metadata.(*ClassMetadata)
+		metadata.MaxEnrollment = studentCount[class.SourcedId] + 5
+		if i%7 == 3 {
+			metadata.MaxEnrollment = max(studentCount[class.SourcedId]-2, 1)
+		}
+	}
+
 	// --- Generate Categories ---
 	ds.Categories = append(ds.Categories,
-		Category{BaseModel: BaseModel{SourcedId: uuid.New().String()}, Title: "Homework", Weight: 20},
-		Category{BaseModel: BaseModel{SourcedId: uuid.New().String()}, Title: "Exams", Weight: 50},
-		Category{BaseModel: BaseModel{SourcedId: uuid.New().String()}, Title: "Participation", Weight: 30},
+		Category{BaseModel: BaseModel{SourcedId: newID("category:Homework"), Status: "active", DateLastModified: time.Now()}, Title: "Homework", Weight: 20},
+		Category{BaseModel: BaseModel{SourcedId: newID("category:Exams"), Status: "active", DateLastModified: time.Now()}, Title: "Exams", Weight: 50},
+		Category{BaseModel: BaseModel{SourcedId: newID("category:Participation"), Status: "active", DateLastModified: time.Now()}, Title: "Participation", Weight: 30},
 	)
 
+	// --- Generate Line Items (homework and an exam per grading period) ---
+	homework, exams := ds.Categories[0], ds.Categories[1]
+	for _, class := range ds.Classes {
+		for p, periodRef := range sessions[class.Terms[0].SourcedId].Children {
+			period := sessions[periodRef.SourcedId]
+			start, _ := time.Parse(time.DateOnly, period.StartDate)
+			end, _ := time.Parse(time.DateOnly, period.EndDate)
+			for _, item := range []struct {
+				title    string
+				category Category
+				due      time.Time
+			}{
+				{fmt.Sprintf("Homework %d", p+1), homework, start.AddDate(0, 0, 14)},
+				{fmt.Sprintf("Exam %d", p+1), exams, end},
+			} {
+				ds.LineItems = append(ds.LineItems, LineItem{
+					BaseModel:      BaseModel{SourcedId: newID("lineItem:%s:%s", class.SourcedId, item.title), Status: "active", DateLastModified: time.Now()},
+					Title:          item.title,
+					Description:    fmt.Sprintf("%s for %s", item.title, class.ClassCode),
+					AssignDate:     start,
+					DueDate:        item.due,
+					Class:          GUIDRef{Href: "/classes/" + class.SourcedId, SourcedId: class.SourcedId, Type: "class"},
+					Category:       GUIDRef{Href: "/categories/" + item.category.SourcedId, SourcedId: item.category.SourcedId, Type: "category"},
+					GradingPeriod:  periodRef,
+					ResultValueMin: 0,
+					ResultValueMax: 100,
+				})
+			}
+		}
+	}
+
+	// --- Generate Results (one per enrolled student per line item) ---
+	// Most results are fully graded; of every twenty, one is exempt and two
+	// each are not submitted, submitted and partially graded. Only graded
+	// results have a score.
+	studentsByClass := make(map[string][]string)
+	for _, seat := range pending.seats {
+		if !seat.teacher {
+			studentsByClass[seat.classId] = append(studentsByClass[seat.classId], seat.user)
+		}
+	}
+	for _, lineItem := range ds.LineItems {
+		for _, student := range studentsByClass[lineItem.Class.SourcedId] {
+			scoreStatus := resultScoreStatus(len(ds.Results))
+			var score *float64
+			if graded(scoreStatus) {
+				score = new(float64)
+				*score = float64(50 + rng.IntN(51))
+			}
+			ds.Results = append(ds.Results, Result{
+				BaseModel:   BaseModel{SourcedId: newID("result:%s:%s", lineItem.SourcedId, student), Status: "active", DateLastModified: time.Now()},
+				LineItem:    GUIDRef{Href: "/lineItems/" + lineItem.SourcedId, SourcedId: lineItem.SourcedId, Type: "lineItem"},
+				Student:     GUIDRef{Href: "/students/" + student, SourcedId: student, Type: "student"},
+				ScoreStatus: scoreStatus,
+				Score:       score,
+				ScoreDate:   lineItem.DueDate.Format(time.DateOnly),
+			})
+		}
+	}
+	ds.rebuildResultIndexes()
+	if cfg.Chaos {
+		ds.removeClassTerms()
+		ds.addOrphanedEnrollments(seed)
+		ds.removeOptionalFields()
+		ds.removeUserOrgs(cfg.Students)
+	}
+	if cfg.SessionTimeZone != nil {
+		ds.timestampSessionDates(cfg.SessionTimeZone)
+	}
+	pending.index()
+	ds.spreadModifiedDates(seed, time.Now())
+	if !cfg.LazyEnrollments {
+		ds.Enrollments = pending.buildAll()
+		ds.pending = nil
+	}
+
 	return ds
 }
+
+// recentUsers and recentClasses size the recently modified subset, a fixed
+// target for delta-sync tests. The first generated users (students 1 to 10,
+// GeneratedID(seed, "student:1") and so on) and classes ("class:1" to
+// "class:5") were last modified a few minutes before startup; every other
+// record between a day and a year before. Until something is written,
+// modifiedSince an hour before startup thus returns exactly this subset.
+// The dates are fixed at startup, so "an hour ago" only does during the
+// first hour of uptime.
+const (
+	recentUsers   = 10
+	recentClasses = 5
+)
+
+// spreadModifiedDates backdates every generated record to a point between
+// one and 366 days before now, chosen from the seed, then pins the recently
+// modified subset to the minutes before now.
+func (ds *DataStore) spreadModifiedDates(seed uint64, now time.Time) {
+	rng := rand.New(rand.NewPCG(seed, ^seed))
+	past := func() time.Time {
+		return now.Add(-24*time.Hour - time.Duration(rng.Int64N(int64(365*24*time.Hour))))
+	}
+	backdate := func(b *BaseModel) {
+		b.DateLastModified = past()
+	}
+	backdateAll(ds.Orgs, backdate)
+	backdateAll(ds.Users, backdate)
+	backdateAll(ds.Courses, backdate)
+	backdateAll(ds.Classes, backdate)
+	// Enrollments are still seats at this point; see lazy.go.
+	for i := range ds.pending.seats {
+		ds.pending.seats[i].modified = past()
+	}
+	backdateAll(ds.AcademicSessions, backdate)
+	backdateAll(ds.Categories, backdate)
+	backdateAll(ds.Resources, backdate)
+	backdateAll(ds.LineItems, backdate)
+	backdateAll(ds.Results, backdate)
+
+	for i := range min(recentUsers, len(ds.Users)) {
+		ds.Users[i].DateLastModified = now.Add(-time.Duration(i+1) * time.Minute)
+	}
+	for i := range min(recentClasses, len(ds.Classes)) {
+		ds.Classes[i].DateLastModified = now.Add(-time.Duration(i+1) * time.Minute)
+	}
+}
+
+func backdateAll[T any, P interface {
+	*T
+	base() *BaseModel
+}](items []T, backdate func(*BaseModel)) {
+	for i := range items {
+		backdate(P(&items[i]).base())
+	}
+}
+
+// addIdentityCollisions gives a few users the identity of another, as real
+// SIS data does, for testing deduplication. Students 11, 21 and 31 share the
+// email of students 10, 20 and 30, and teachers 5 and 15 the username of
+// students 5 and 15. Each copy is otherwise valid and marked in its metadata
+// with CollidesWith. students is the number of students, which come before
+// the teachers in Users.
+func (ds *DataStore) addIdentityCollisions(students int) {
+	collide := func(copy, original int, email bool) {
+		if copy >= len(ds.Users) || original >= students {
+			return
+		}
+		user, source := &ds.Users[copy], ds.Users[original]
+		if email {
+			user.Email = source.Email
+		} else {
+			user.Username = source.Username
+		}
+		user.Metadata.(*UserMetadata).CollidesWith = source.SourcedId
+	}
+	for _, n := range []int{10, 20, 30} {
+		if n < students {
+			collide(n, n-1, true)
+		}
+	}
+	for _, n := range []int{5, 15} {
+		collide(students+n-1, n-1, false)
+	}
+}
+
+// edgeCaseNames are the names chaos mode gives students 41 to 45, in turn.
+// The long family name is 255 characters, a common column limit.
+var edgeCaseNames = []struct{ edgeCase, given, family string }{
+	{"apostrophe", "D'Arcy", "O'Connor"},
+	{"hyphen", "Mary-Jane", "Smith-Jones"},
+	{"emoji", "Zoë 🌟", "Nguyễn 🎓"},
+	{"long", "Maximilian", strings.Repeat("Wolfeschlegel", 19) + "steinhau"},
+	{"whitespace", "  Leading", "Trailing  "},
+}
+
+// addEdgeCaseNames renames students 41 to 45 after edgeCaseNames, for
+// testing escaping, truncation and layout. Each is marked in its metadata
+// with NameEdgeCase. students is the number of students, which come first
+// in Users.
+func (ds *DataStore) addEdgeCaseNames(students int) {
+	for k, name := range edgeCaseNames {
+		i := 40 + k
+		if i >= students {
+			return
+		}
+		user := &ds.Users[i]
+		user.GivenName, user.FamilyName = name.given, name.family
+		user.Metadata.(*UserMetadata).NameEdgeCase = name.edgeCase
+	}
+}
+
+// Chaos mode leaves these generated students and teachers ("student:51" and
+// so on) without an org.
+var (
+	orglessStudents = []int{51, 71}
+	orglessTeachers = []int{7}
+)
+
+// transferEvery spaces the students who transfer: students 12, 37, 62 and so
+// on, one in twenty-five.
+const transferEvery = 25
+
+// addTransfers moves every transferEvery-th student to another school
+// partway through the latest term they have classes in, for testing
+// enrollment history across schools. Their enrollments at their old school
+// in that term end transferWeeks into it; they are enrolled in as many
+// classes of their grade and term at the next school that has any, starting
+// a week later. Their orgs name the new school, as their enrollments of
+// earlier terms name the old one. A student without enrollments, or for whom
+// no other school has a class, stays put. students is the number of
+// students, which come first in Users.
+func (ds *DataStore) addTransfers(students int, schools []Org, termOfClass map[string]string, classesByTerm map[[3]string][]int) {
+	leaving := make(map[string][]int)
+	for i := transferEvery / 2; i <= students; i += transferEvery {
+		leaving[ds.Users[i-1].SourcedId] = nil
+	}
+	p := ds.pending
+	for n, seat := range p.seats {
+		seats, ok := leaving[seat.user]
+		if !ok || seat.teacher {
+			continue
+		}
+		// Keep only the seats of the latest term.
+		if len(seats) > 0 {
+			latest := p.classes[p.seats[seats[0]].class].begin
+			if begin := p.classes[seat.class].begin; begin.After(latest) {
+				seats = nil
+			} else if begin.Before(latest) {
+				continue
+			}
+		}
+		leaving[seat.user] = append(seats, n)
+	}
+	for i := transferEvery / 2; i <= students; i += transferEvery {
+		student := &ds.Users[i-1]
+		seats := leaving[student.SourcedId]
+		if len(seats) == 0 {
+			continue
+		}
+		term := termOfClass[p.seats[seats[0]].classId]
+		var to Org
+		var classes []int
+		for d := 1; d < len(schools) && len(classes) == 0; d++ {
+			to = schools[(i+d)%len(schools)]
+			classes = classesByTerm[[3]string{to.SourcedId, term, student.Grades[0]}]
+		}
+		if len(classes) == 0 {
+			continue
+		}
+		for _, n := range seats {
+			p.seats[n].transfer = "out"
+		}
+		for k := range min(len(seats), len(classes)) {
+			p.add(classes[(i+k)%len(classes)], student.SourcedId, false, false)
+			p.seats[len(p.seats)-1].transfer = "in"
+		}
+		_, joined := transferDates(p.classes[classes[0]].begin)
+		metadata := student.Metadata.(*UserMetadata)
+		metadata.TransferredFrom = schools[i%len(schools)].SourcedId
+		metadata.TransferDate = joined.Format(time.DateOnly)
+		student.Orgs = []GUIDRef{{Href: "/orgs/" + to.SourcedId, SourcedId: to.SourcedId, Type: "org"}}
+	}
+}
+
+// transferWeeks is how far into a term transferring students leave.
+const transferWeeks = 6
+
+// transferDates returns a transferring student's last day at their old
+// school and first day at the new one, in the term starting on start.
+func transferDates(start time.Time) (left, joined time.Time) {
+	left = start.AddDate(0, 0, 7*transferWeeks)
+	return left, left.AddDate(0, 0, 7)
+}
+
+// removeUserOrgs empties the orgs of the orglessStudents and
+// orglessTeachers, as in some partner exports, for testing consumers that
+// assume every user has an org. It runs once the rest of the data is
+// generated, so these users keep their enrollments, but they drop out of
+// ?orgSourcedId= and the school-scoped user routes. Each is marked in its
+// metadata with Orgless. students is the number of students, which come
+// before the teachers in Users.
+func (ds *DataStore) removeUserOrgs(students int) {
+	var indexes []int
+	for _, n := range orglessStudents {
+		if n <= students {
+			indexes = append(indexes, n-1)
+		}
+	}
+	for _, n := range orglessTeachers {
+		indexes = append(indexes, students+n-1)
+	}
+	for _, i := range indexes {
+		if i >= len(ds.Users) {
+			continue
+		}
+		user := &ds.Users[i]
+		user.Orgs = []GUIDRef{}
+		user.Metadata.(*UserMetadata).Orgless = true
+	}
+}
+
+// termlessClasses are the generated classes ("class:8" and so on) that chaos
+// mode leaves without a term.
+var termlessClasses = []int{8, 18, 28}
+
+// removeClassTerms empties the terms of the termlessClasses, as some SIS data
+// does, for testing term resolution. It runs once the rest of the data is
+// generated, so these classes keep the enrollments, line items and results
+// of their former term and are otherwise valid. Each is marked in its
+// metadata with Termless.
+func (ds *DataStore) removeClassTerms() {
+	for _, n := range termlessClasses {
+		if n > len(ds.Classes) {
+			continue
+		}
+		class := &ds.Classes[n-1]
+		class.Terms = []GUIDRef{}
+		class.Metadata.(*ClassMetadata).Termless = true
+	}
+}
+
+// Chaos mode leaves these optional fields, by JSON key, out of the generated
+// classes and courses with the given numbers ("class:38" and so on).
+var (
+	omittedClassFields = map[int][]string{
+		38: {"grades"},
+		48: {"subjects"},
+		58: {"location", "periods"},
+	}
+	omittedCourseFields = map[int][]string{
+		7:  {"grades"},
+		17: {"subjects"},
+	}
+)
+
+// removeOptionalFields leaves the fields listed in omittedClassFields and
+// omittedCourseFields out of those classes and courses, as the spec allows,
+// for testing that consumers don't rely on them. Each is marked in its
+// metadata with Omitted. Like removeClassTerms it runs once the rest of the
+// data is generated.
+func (ds *DataStore) removeOptionalFields() {
+	for n, fields := range omittedClassFields {
+		if n > len(ds.Classes) {
+			continue
+		}
+		class := &ds.Classes[n-1]
+		for _, field := range fields {
+			switch field {
+			case "grades":
+				class.Grades = []string{}
+			case "subjects":
+				class.Subjects = []string{}
+			case "location":
+				class.Location = ""
+			case "periods":
+				class.Periods = nil
+			}
+		}
+		class.Metadata.(*ClassMetadata).Omitted = fields
+	}
+	for n, fields := range omittedCourseFields {
+		if n > len(ds.Courses) {
+			continue
+		}
+		course := &ds.Courses[n-1]
+		for _, field := range fields {
+			switch field {
+			case "grades":
+				course.Grades = nil
+			case "subjects":
+				course.Subjects = nil
+			}
+		}
+		course.Metadata = &CourseMetadata{Omitted: fields}
+	}
+}
+
+// orphanedEnrollments are the generated enrollments that chaos mode copies
+// with their user or class replaced by one that doesn't exist.
+var orphanedEnrollments = []struct {
+	n         int
+	reference string
+}{{10, "user"}, {60, "class"}, {110, "user"}, {160, "class"}}
+
+// addOrphanedEnrollments adds a few enrollments whose user or class doesn't
+// exist, as left behind in SIS data when a record is purged, for testing
+// dangling references. Each copies the n-th enrollment, so it is otherwise
+// valid, and is marked in its metadata with Orphaned. The missing user or
+// class has the sourcedId GeneratedID(seed, "orphan:user:10") and so on.
+func (ds *DataStore) addOrphanedEnrollments(seed uint64) {
+	for _, orphan := range orphanedEnrollments {
+		if orphan.n >= len(ds.pending.seats) {
+			continue
+		}
+		ds.pending.addOrphan(orphan.n, orphan.reference, GeneratedID(seed, fmt.Sprintf("orphan:%s:%d", orphan.reference, orphan.n)))
+	}
+}
+
+// gradeLevels are the grades of generated students and classes, as CEDS
+// grade level codes.
+var gradeLevels = []string{"09", "10", "11", "12"}
+
+// gradeLevel returns the grade of the i-th generated student or class. Both
+// are dealt to the schools in turn, so i/schools counts within a school and
+// each school gets every grade alike.
+func gradeLevel(i, schools int) string {
+	return gradeLevels[(i/schools)%len(gradeLevels)]
+}
+
+// languages are the preferred languages given to generated users in turn.
+var languages = []string{"en", "en", "en", "es", "fr", "zh"}
+
+// newStudent returns the i-th generated student, attending school in grade,
+// with a name from the given locale.
+func newStudent(userId string, i int, school Org, grade, locale string) User {
+	given, family := personName(locale, "student", i)
+	return User{
+		BaseModel: BaseModel{SourcedId: userId, Status: "active", DateLastModified: time.Now(),
+			Metadata: &UserMetadata{PreferredLanguage: languages[i%len(languages)], Accommodations: i%8 == 0}},
+		Username:    fmt.Sprintf("student%d", i),
+		EnabledUser: true,
+		GivenName:   given,
+		FamilyName:  family,
+		Role:        "student",
+		Roles:       []string{"student"},
+		Identifier:  fmt.Sprintf("STU%04d", i),
+		Email:       fmt.Sprintf("student%d@example.com", i),
+		Orgs:        []GUIDRef{{Href: "/orgs/" + school.SourcedId, SourcedId: school.SourcedId, Type: "org"}},
+		Grades:      []string{grade},
+	}
+}
+
+// resultScoreStatus returns the score status of the n-th generated result.
+func resultScoreStatus(n int) string {
+	switch n % 20 {
+	case 0:
+		return "exempt"
+	case 1, 2:
+		return "not submitted"
+	case 3, 4:
+		return "submitted"
+	case 5, 6:
+		return "partially graded"
+	}
+	return "fully graded"
+}
+
+// deliveryMode returns the delivery mode of the i-th generated class.
+func deliveryMode(i int) string {
+	switch {
+	case i%10 == 0:
+		return "online"
+	case i%5 == 0:
+		return "hybrid"
+	}
+	return "in-person"
+}
+
+// timestampSessionDates rewrites the plain start and end dates of the
+// academic sessions as RFC 3339 timestamps in loc: the start of the first
+// day and the last second of the last. Generation reads the plain dates, so
+// this runs once it is done.
+func (ds *DataStore) timestampSessionDates(loc *time.Location) {
+	for i := range ds.AcademicSessions {
+		session := &ds.AcademicSessions[i]
+		if start, err := time.ParseInLocation(time.DateOnly, session.StartDate, loc); err == nil {
+			session.StartDate = start.Format(time.RFC3339)
+		}
+		if end, err := time.ParseInLocation(time.DateOnly, session.EndDate, loc); err == nil {
+			session.EndDate = end.Add(24*time.Hour - time.Second).Format(time.RFC3339)
+		}
+	}
+}
+
+// dateOnly returns the date part of a session date, which is either a plain
+// date or, with MOCK_SESSION_TZ_OFFSET, a timestamp starting with one.
+func dateOnly(date string) string {
+	if len(date) > len(time.DateOnly) {
+		return date[:len(time.DateOnly)]
+	}
+	return date
+}
+
+// classLocation returns the location of the i-th generated class: its room,
+// unless it is online.
+func classLocation(i int) string {
+	if deliveryMode(i) == "online" {
+		return "Online"
+	}
+	return fmt.Sprintf("Room %d", 101+i%40)
+}
+
+// rebuildResultIndexes recomputes resultsByStudent and resultsByClass from
+// Results and LineItems.
+func (ds *DataStore) rebuildResultIndexes() {
+	classOfLineItem := make(map[string]string, len(ds.LineItems))
+	for _, lineItem := range ds.LineItems {
+		classOfLineItem[lineItem.SourcedId] = lineItem.Class.SourcedId
+	}
+	ds.resultsByStudent = make(map[string][]*Result)
+	ds.resultsByClass = make(map[string][]*Result)
+	for i := range ds.Results {
+		result := &ds.Results[i]
+		ds.resultsByStudent[result.Student.SourcedId] = append(ds.resultsByStudent[result.Student.SourcedId], result)
+		classId := classOfLineItem[result.LineItem.SourcedId]
+		ds.resultsByClass[classId] = append(ds.resultsByClass[classId], result)
+	}
+}
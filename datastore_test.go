@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// largeStore is a store with tens of thousands of results, generated once
+// for the benchmarks that need one.
+var largeStore = sync.OnceValue(func() *DataStore {
+	cfg := DefaultConfig()
+	cfg.Seed = 7
+	cfg.Students, cfg.Teachers, cfg.Classes = 5000, 250, 1000
+	return NewDataStore(cfg)
+})
+
+// sink keeps benchmarked lookups from being optimized away.
+var sink int
+
+// BenchmarkResultsByStudent compares looking up a student's results in the
+// resultsByStudent index with scanning every result.
+func BenchmarkResultsByStudent(b *testing.B) {
+	ds := largeStore()
+	b.Logf("%d results", len(ds.Results))
+	students := make([]string, 0, len(ds.Users))
+	for _, user := range ds.Users {
+		if user.hasRole("student") {
+			students = append(students, user.SourcedId)
+		}
+	}
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			sink += len(ds.resultsByStudent[students[i%len(students)]])
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			student := students[i%len(students)]
+			var results []*Result
+			for j := range ds.Results {
+				if ds.Results[j].Student.SourcedId == student {
+					results = append(results, &ds.Results[j])
+				}
+			}
+			sink += len(results)
+		}
+	})
+}
+
+// BenchmarkResultsByClass compares looking up a class's results in the
+// resultsByClass index with scanning every line item and result.
+func BenchmarkResultsByClass(b *testing.B) {
+	ds := largeStore()
+	b.Logf("%d results", len(ds.Results))
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			sink += len(ds.resultsByClass[ds.Classes[i%len(ds.Classes)].SourcedId])
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			class := ds.Classes[i%len(ds.Classes)].SourcedId
+			lineItems := make(map[string]bool)
+			for _, lineItem := range ds.LineItems {
+				if lineItem.Class.SourcedId == class {
+					lineItems[lineItem.SourcedId] = true
+				}
+			}
+			var results []*Result
+			for j := range ds.Results {
+				if lineItems[ds.Results[j].LineItem.SourcedId] {
+					results = append(results, &ds.Results[j])
+				}
+			}
+			sink += len(results)
+		}
+	})
+}
+
+// TestGeneratedID checks that GeneratedID gives the ids NewDataStore does for
+// each kind of business key documented on it.
+func TestGeneratedID(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.Chaos = true
+	ds := NewDataStore(cfg)
+	id := func(format string, args ...any) string { return GeneratedID(cfg.Seed, fmt.Sprintf(format, args...)) }
+
+	session := func(typ, schoolYear string, n int) string {
+		var matches []AcademicSession
+		for _, s := range ds.AcademicSessions {
+			if s.Type == typ && s.SchoolYear == schoolYear {
+				matches = append(matches, s)
+			}
+		}
+		slices.SortFunc(matches, func(a, b AcademicSession) int { return strings.Compare(a.StartDate, b.StartDate) })
+		return matches[n].SourcedId
+	}
+	category := slices.IndexFunc(ds.Categories, func(c Category) bool { return c.Title == "Homework" })
+	for key, want := range map[string]string{
+		id("district:1"):           ds.Orgs[0].SourcedId,
+		id("school:1"):             ds.Orgs[1].SourcedId,
+		id("student:1"):            ds.Users[0].SourcedId,
+		id("teacher:1"):            ds.Users[cfg.Students].SourcedId,
+		id("course:1"):             ds.Courses[0].SourcedId,
+		id("class:1"):              ds.Classes[0].SourcedId,
+		id("schoolYear:2025"):      session("schoolYear", "2025", 0),
+		id("semester:2025:1"):      session("semester", "2025", 0),
+		id("semester:2025:2"):      session("semester", "2025", 1),
+		id("term:2025"):            session("term", "2025", 0),
+		id("gradingPeriod:2025:2"): session("gradingPeriod", "2025", 1),
+		id("category:Homework"):    ds.Categories[category].SourcedId,
+	} {
+		if key != want {
+			t.Errorf("GeneratedID gives %s where NewDataStore gave %s", key, want)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, resource := range ds.Resources {
+		ids[resource.SourcedId] = true
+	}
+	for _, course := range ds.Courses {
+		for _, kind := range []string{"textbook", "guide"} {
+			if !ids[id("resource:%s:%s", course.SourcedId, kind)] {
+				t.Errorf("course %s: no %s resource with the generated id", course.SourcedId, kind)
+			}
+		}
+	}
+	for _, class := range ds.Classes {
+		if class.Location != "" && class.Location != "Online" && !ids[id("resource:room:%s:%s", class.School.SourcedId, class.Location)] {
+			t.Errorf("class %s: no room resource for %q with the generated id", class.SourcedId, class.Location)
+		}
+	}
+	for _, lineItem := range ds.LineItems {
+		if lineItem.SourcedId != id("lineItem:%s:%s", lineItem.Class.SourcedId, lineItem.Title) {
+			t.Errorf("line item %s: id is not that of its class and title", lineItem.SourcedId)
+		}
+	}
+	for _, result := range ds.Results {
+		if result.SourcedId != id("result:%s:%s", result.LineItem.SourcedId, result.Student.SourcedId) {
+			t.Errorf("result %s: id is not that of its line item and student", result.SourcedId)
+		}
+	}
+
+	missing := make(map[string]bool)
+	for _, enrollment := range ds.enrollments() {
+		if m, ok := enrollment.Metadata.(*EnrollmentMetadata); ok && m.Orphaned != "" {
+			missing[map[string]string{"user": enrollment.User.SourcedId, "class": enrollment.Class.SourcedId}[m.Orphaned]] = true
+		} else if enrollment.SourcedId != id("enrollment:%s:%s", enrollment.Class.SourcedId, enrollment.User.SourcedId) {
+			t.Errorf("enrollment %s: id is not that of its class and user", enrollment.SourcedId)
+		}
+	}
+	for _, orphan := range orphanedEnrollments {
+		if !missing[id("orphan:%s:%d", orphan.reference, orphan.n)] {
+			t.Errorf("no orphaned enrollment references %s orphan:%s:%d", orphan.reference, orphan.reference, orphan.n)
+		}
+	}
+}
+
+// TestRecentlyModified checks that modifiedSince an hour before generation
+// returns exactly the recently modified subset.
+func TestRecentlyModified(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	since := url.QueryEscape(time.Now().Add(-time.Hour).Format(time.RFC3339))
+	for path, want := range map[string]int{"/users": recentUsers, "/classes": recentClasses} {
+		rec := s.get(t, path+"?modifiedSince="+since)
+		var got int
+		for _, items := range decode[map[string][]json.RawMessage](t, rec) {
+			got += len(items)
+		}
+		if got != want {
+			t.Errorf("GET %s?modifiedSince=%s: %d items, want %d", path, since, got, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DeleteRequest is the body accepted by POST /admin/delete.
+type DeleteRequest struct {
+	EntityType string   `json:"entityType"` // an envelope singular, e.g. "enrollment"
+	SourcedIds []string `json:"sourcedIds"`
+}
+
+// DeleteResult reports what POST /admin/delete did with one sourcedId:
+// "deleted" (marked tobedeleted), "removed" (hard delete) or "notFound".
+type DeleteResult struct {
+	SourcedId string `json:"sourcedId"`
+	Result    string `json:"result"`
+}
+
+// DeleteResponse is the body returned by POST /admin/delete, with one result
+// per requested sourcedId, in request order.
+type DeleteResponse struct {
+	Results []DeleteResult `json:"results"`
+}
+
+// postDelete handles bulk deletes for test teardown. By default the records
+// are soft-deleted: their status becomes tobedeleted and they count as
+// written now. With ?hard=true they are removed from the store instead.
+// Nothing cascades, so removing a user leaves its enrollments dangling.
+func (h *APIHandlers) postDelete(w http.ResponseWriter, r *http.Request) {
+	hard := false
+	if value := r.URL.Query().Get("hard"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid hard value: must be true or false")
+			return
+		}
+		hard = b
+	}
+	var req DeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if _, ok := envelopeBySingular(req.EntityType); !ok {
+		writeError(w, http.StatusBadRequest, invalidEntityType(req.EntityType))
+		return
+	}
+	if len(req.SourcedIds) > maxWriteBatch {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many sourcedIds: at most %d per request", maxWriteBatch))
+		return
+	}
+
+	ds := h.Store
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	now := ds.now()
+	var results []DeleteResult
+	switch req.EntityType {
+	case orgEnvelope.singular:
+		ds.Orgs, results = deleteRecords(ds, ds.Orgs, req.SourcedIds, hard, now)
+	case userEnvelope.singular:
+		ds.Users, results = deleteRecords(ds, ds.Users, req.SourcedIds, hard, now)
+	case courseEnvelope.singular:
+		ds.Courses, results = deleteRecords(ds, ds.Courses, req.SourcedIds, hard, now)
+	case classEnvelope.singular:
+		ds.Classes, results = deleteRecords(ds, ds.Classes, req.SourcedIds, hard, now)
+	case enrollmentEnvelope.singular:
+		ds.Enrollments, results = deleteRecords(ds, ds.enrollments(), req.SourcedIds, hard, now)
+	case academicSessionEnvelope.singular:
+		ds.AcademicSessions, results = deleteRecords(ds, ds.AcademicSessions, req.SourcedIds, hard, now)
+	case categoryEnvelope.singular:
+		ds.Categories, results = deleteRecords(ds, ds.Categories, req.SourcedIds, hard, now)
+	case lineItemEnvelope.singular:
+		ds.LineItems, results = deleteRecords(ds, ds.LineItems, req.SourcedIds, hard, now)
+		ds.rebuildResultIndexes()
+	case resultEnvelope.singular:
+		ds.Results, results = deleteRecords(ds, ds.Results, req.SourcedIds, hard, now)
+		ds.rebuildResultIndexes()
+	case resourceEnvelope.singular:
+		ds.Resources, results = deleteRecords(ds, ds.Resources, req.SourcedIds, hard, now)
+	}
+	writeJSON(w, http.StatusOK, DeleteResponse{Results: results})
+}
+
+// deleteRecords soft- or hard-deletes the items with the given sourcedIds and
+// returns the remaining items along with a result per id.
+func deleteRecords[T any, P interface {
+	*T
+	base() *BaseModel
+}](ds *DataStore, items []T, ids []string, hard bool, now time.Time) ([]T, []DeleteResult) {
+	index := make(map[string]int, len(items))
+	for i := range items {
+		index[P(&items[i]).base().SourcedId] = i
+	}
+	results := make([]DeleteResult, len(ids))
+	removed := make(map[string]bool)
+	for k, id := range ids {
+		results[k] = DeleteResult{SourcedId: id, Result: "notFound"}
+		i, ok := index[id]
+		if !ok {
+			continue
+		}
+		if hard {
+			removed[id] = true
+			ds.changes.record(id, now)
+			results[k].Result = "removed"
+			continue
+		}
+		record := P(&items[i]).base()
+		record.Status = "tobedeleted"
+		record.DateLastModified = now
+		ds.markWritten(id)
+		results[k].Result = "deleted"
+	}
+	if len(removed) > 0 {
+		items = slices.DeleteFunc(items, func(item T) bool { return removed[P(&item).base().SourcedId] })
+	}
+	return items, results
+}
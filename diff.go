@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxDiffRecords caps how many records a single diff request may carry.
+const maxDiffRecords = 10000
+
+// DiffRequest is the body accepted by POST /admin/diff: the records of one
+// entity type an external system holds.
+type DiffRequest struct {
+	EntityType string       `json:"entityType"` // an envelope singular, e.g. "user"
+	Records    []DiffRecord `json:"records"`
+}
+
+// DiffRecord identifies one external record and its content. Hash is the
+// record's ETag as served by this mock, with or without the quotes.
+type DiffRecord struct {
+	SourcedId string `json:"sourcedId"`
+	Hash      string `json:"hash"`
+}
+
+// DiffResponse is the body returned by POST /admin/diff. Each list is
+// ordered as the records of the request or, for extra, of the store.
+type DiffResponse struct {
+	// Missing are the requested sourcedIds the store doesn't have.
+	Missing []string `json:"missing"`
+	// Extra are the sourcedIds the store has but the request left out.
+	Extra []string `json:"extra"`
+	// Changed are the records whose hash differs, with the store's hash.
+	Changed []DiffRecord `json:"changed"`
+	// Unchanged counts the records whose hash matches.
+	Unchanged int `json:"unchanged"`
+}
+
+// postDiff handles reconciliation of an external dataset against the store,
+// for sync verification. It compares the records of one entity type by
+// sourcedId and content hash and reports which are missing, extra or
+// changed.
+func (h *APIHandlers) postDiff(w http.ResponseWriter, r *http.Request) {
+	var req DiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if _, ok := envelopeBySingular(req.EntityType); !ok {
+		writeError(w, http.StatusBadRequest, invalidEntityType(req.EntityType))
+		return
+	}
+	if len(req.Records) > maxDiffRecords {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many records: at most %d per request", maxDiffRecords))
+		return
+	}
+	seen := make(map[string]bool, len(req.Records))
+	for i, record := range req.Records {
+		switch {
+		case record.SourcedId == "":
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("records[%d]: sourcedId is required", i))
+			return
+		case seen[record.SourcedId]:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("records[%d]: duplicate sourcedId %q", i, record.SourcedId))
+			return
+		}
+		seen[record.SourcedId] = true
+	}
+
+	ds := h.Store
+	var resp DiffResponse
+	switch req.EntityType {
+	case orgEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Orgs), req.Records)
+	case userEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Users), req.Records)
+	case courseEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Courses), req.Records)
+	case classEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Classes), req.Records)
+	case enrollmentEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.enrollments()), req.Records)
+	case academicSessionEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.AcademicSessions), req.Records)
+	case categoryEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Categories), req.Records)
+	case lineItemEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.LineItems), req.Records)
+	case resultEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Results), req.Records)
+	case resourceEnvelope.singular:
+		resp = diffRecords(visibleOnly(ds, ds.Resources), req.Records)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// diffRecords compares records, which have distinct sourcedIds, against
+// items.
+func diffRecords[T entity](items []T, records []DiffRecord) DiffResponse {
+	resp := DiffResponse{Missing: []string{}, Extra: []string{}, Changed: []DiffRecord{}}
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item.sourcedID()] = i
+	}
+	requested := make(map[string]bool, len(records))
+	for _, record := range records {
+		requested[record.SourcedId] = true
+		i, ok := index[record.SourcedId]
+		if !ok {
+			resp.Missing = append(resp.Missing, record.SourcedId)
+			continue
+		}
+		hash := strings.Trim(entityTag(items[i]), `"`)
+		if strings.Trim(record.Hash, `"`) != hash {
+			resp.Changed = append(resp.Changed, DiffRecord{SourcedId: record.SourcedId, Hash: hash})
+			continue
+		}
+		resp.Unchanged++
+	}
+	for _, item := range items {
+		if !requested[item.sourcedID()] {
+			resp.Extra = append(resp.Extra, item.sourcedID())
+		}
+	}
+	return resp
+}
@@ -38,6 +38,98 @@ const docTemplate = `{
                     "Academic Sessions"
                 ],
                 "summary": "Get all academic sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='term' AND startDate\u003e='2025-01-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -100,21 +192,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/classes": {
+        "/academicSessions/{id}/classes": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all scheduled classes.",
+                "description": "Retrieves the classes whose terms reference the academic session or any session below it, so a school year returns the classes of all its terms.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Classes"
+                    "Academic Sessions"
+                ],
+                "summary": "Get classes for an academic session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the academic session",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. course.sourcedId='\u003cid\u003e' AND classType='scheduled'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all classes",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -127,32 +318,130 @@ const docTemplate = `{
                                 }
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/classes/{id}": {
+        "/categories": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single class by its sourcedId.",
+                "description": "Retrieves a collection of all grading categories, optionally only those weighing at least minWeight.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Classes"
+                    "Categories"
                 ],
-                "summary": "Get a specific class",
+                "summary": "Get all categories",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the class",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. weight\u003e='20'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return categories whose weight is at least this",
+                        "name": "minWeight",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -161,12 +450,15 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "$ref": "#/definitions/main.Class"
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Category"
+                                }
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -177,28 +469,135 @@ const docTemplate = `{
                 }
             }
         },
-        "/classes/{id}/categories": {
+        "/classes": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of grading categories for a given class.",
+                "description": "Retrieves a collection of all scheduled classes, optionally only those a given teacher or student is enrolled in and/or those matching a search.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Classes"
                 ],
-                "summary": "Get categories for a class",
+                "summary": "Get all classes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the class",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. course.sourcedId='\u003cid\u003e' AND classType='scheduled'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return classes the user with this sourcedId teaches",
+                        "name": "teacherSourcedId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return classes the user with this sourcedId is enrolled in as a student",
+                        "name": "studentSourcedId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return classes whose title, classCode or a subject contains this text, case-insensitively",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to enrollmentCount to add each class's number of student enrollments as _enrollmentCount",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -209,29 +608,52 @@ const docTemplate = `{
                             "additionalProperties": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/main.Category"
+                                    "$ref": "#/definitions/main.Class"
                                 }
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/courses": {
-            "get": {
+        "/classes/lookup": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all courses from the catalog.",
+                "description": "Resolves up to 1000 class sourcedIds in one request. Unknown ids are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "Classes"
+                ],
+                "summary": "Look up classes by sourcedId",
+                "parameters": [
+                    {
+                        "description": "SourcedIds to resolve",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.LookupRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all courses",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -240,36 +662,51 @@ const docTemplate = `{
                             "additionalProperties": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/main.Course"
+                                    "$ref": "#/definitions/main.Class"
                                 }
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/courses/{id}": {
+        "/classes/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single course by its sourcedId.",
+                "description": "Retrieves a single class by its sourcedId.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "Classes"
                 ],
-                "summary": "Get a specific course",
+                "summary": "Get a specific class",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the course",
+                        "description": "SourcedId of the class",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to enrollmentCount to add the class's number of student enrollments as _enrollmentCount",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -278,7 +715,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "$ref": "#/definitions/main.Course"
+                                "$ref": "#/definitions/main.Class"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -294,21 +740,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/enrollments": {
+        "/classes/{id}/academicSessions": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all user enrollments in classes.",
+                "description": "Retrieves every academic session related to a class: its terms, the sessions above them (e.g. the school year) and the sessions below them (e.g. grading periods), each once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enrollments"
+                    "Classes"
+                ],
+                "summary": "Get academic sessions for a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='term' AND startDate\u003e='2025-01-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all enrollments",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -317,33 +862,42 @@ const docTemplate = `{
                             "additionalProperties": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/main.Enrollment"
+                                    "$ref": "#/definitions/main.AcademicSession"
                                 }
                             }
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/enrollments/{id}": {
+        "/classes/{id}/capacity": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single enrollment by its sourcedId.",
+                "description": "Compares the class's maxEnrollment metadata with the number of students enrolled in it. Classes without a cap report a null maxEnrollment and are never over capacity.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enrollments"
+                    "Classes"
                 ],
-                "summary": "Get a specific enrollment",
+                "summary": "Get the capacity of a class",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the enrollment",
+                        "description": "SourcedId of the class",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -353,10 +907,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/main.Enrollment"
-                            }
+                            "$ref": "#/definitions/main.ClassCapacity"
                         }
                     },
                     "404": {
@@ -371,21 +922,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/gradingPeriods": {
+        "/classes/{id}/categories": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all academic sessions with type 'gradingPeriod'.",
+                "description": "Retrieves a collection of grading categories for a given class, optionally only those weighing at least minWeight.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Academic Sessions"
+                    "Classes"
+                ],
+                "summary": "Get categories for a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. weight\u003e='20'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return categories whose weight is at least this",
+                        "name": "minWeight",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all grading periods",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -394,33 +1050,42 @@ const docTemplate = `{
                             "additionalProperties": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/main.AcademicSession"
+                                    "$ref": "#/definitions/main.Category"
                                 }
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/gradingPeriods/{id}": {
+        "/classes/{id}/metadata": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single grading period by its sourcedId.",
+                "description": "Retrieves only the metadata extension block of a class. Classes without metadata return an empty object.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Academic Sessions"
+                    "Classes"
                 ],
-                "summary": "Get a specific grading period",
+                "summary": "Get a class's metadata",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the grading period",
+                        "description": "SourcedId of the class",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -430,10 +1095,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/main.AcademicSession"
-                            }
+                            "$ref": "#/definitions/main.ClassMetadata"
                         }
                     },
                     "404": {
@@ -448,46 +1110,130 @@ const docTemplate = `{
                 }
             }
         },
-        "/orgs": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a collection of all organizations, including schools and districts.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Orgs"
-                ],
-                "summary": "Get all organizations",
-                "responses": {}
-            }
-        },
-        "/orgs/{id}": {
+        "/classes/{id}/resources": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single organization by its sourcedId.",
+                "description": "Retrieves the learning resources a class links to, optionally only those meant for a role and/or of an importance.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orgs"
+                    "Resources"
                 ],
-                "summary": "Get a specific organization",
+                "summary": "Get resources for a class",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the organization",
+                        "description": "SourcedId of the class",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. importance='primary'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return resources whose roles include this one",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return resources of this importance: primary or secondary",
+                        "name": "importance",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -496,7 +1242,19 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "$ref": "#/definitions/main.Org"
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Resource"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -512,59 +1270,4392 @@ const docTemplate = `{
                 }
             }
         },
-        "/schools": {
+        "/classes/{id}/results": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all organizations with type 'school'.",
+                "description": "Retrieves a collection of results for all line items of a given class, ordered by scoreDate by default.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Schools"
+                    "Results"
                 ],
-                "summary": "Get all schools",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Get results for a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score\u003e='90'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Result"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/students": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the users enrolled as students in a class, optionally only those in a grade.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Classes"
+                ],
+                "summary": "Get students for a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return students in this grade, e.g. 10",
+                        "name": "grade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/students/{studentId}/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of the results of a student for the line items of a class the student is enrolled in, ordered by scoreDate by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Results"
+                ],
+                "summary": "Get results for a student in a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "studentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score\u003e='90'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Result"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/teachers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the users enrolled as teachers in a class. Co-taught classes return more than one teacher.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Classes"
+                ],
+                "summary": "Get teachers for a class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the class",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/courses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all courses from the catalog, optionally limited to one school year and/or to those matching a search.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get all courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. title~'Math' AND schoolYear.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return courses in the schoolYear academic session with this sourcedId",
+                        "name": "schoolYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return courses whose title, courseCode or a subject contains this text, case-insensitively",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Course"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single course by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get a specific course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the course",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Course"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/prerequisites": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the courses that must be completed before a given course. Courses without prerequisites return an empty collection.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get prerequisites for a course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the course",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. title~'Math' AND schoolYear.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Course"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all user enrollments in classes, optionally filtered by role, class and primary flag.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollments"
+                ],
+                "summary": "Get all enrollments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. role='teacher' AND primary='true'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return enrollments with this role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return enrollments in the class with this sourcedId",
+                        "name": "classSourcedId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return enrollments whose primary flag matches",
+                        "name": "primary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return enrollments in this state on the server's current date: upcoming (beginDate after it), active or ended (endDate before it)",
+                        "name": "lifecycleState",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Enrollment"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments/lookup": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Resolves up to 1000 enrollment sourcedIds in one request. Unknown ids are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollments"
+                ],
+                "summary": "Look up enrollments by sourcedId",
+                "parameters": [
+                    {
+                        "description": "SourcedIds to resolve",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.LookupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Enrollment"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single enrollment by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollments"
+                ],
+                "summary": "Get a specific enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the enrollment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Enrollment"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores the enrollment under the sourcedId in the path. The user and class must exist, and the role must be permitted for the class's type: homeroom classes accept administrator, student and teacher; scheduled classes accept proctor, student and teacher.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollments"
+                ],
+                "summary": "Create or replace an enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the enrollment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enrollment to write",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EnrollmentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the enrollment as last read; the write fails with 412 if it has changed since",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Enrollment"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Enrollment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/enrollments/{id}/related": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves an enrollment with its user, class, the class's course, the school and the class's terms resolved in one object.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollments"
+                ],
+                "summary": "Get an enrollment with its related records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the enrollment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.EnrollmentRelated"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/gradingPeriods": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all academic sessions with type 'gradingPeriod'.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Sessions"
+                ],
+                "summary": "Get all grading periods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='term' AND startDate\u003e='2025-01-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.AcademicSession"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/gradingPeriods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single grading period by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Sessions"
+                ],
+                "summary": "Get a specific grading period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the grading period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.AcademicSession"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/gradingPeriods/{id}/lineItems": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the line items whose gradingPeriod references the grading period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Line Items"
+                ],
+                "summary": "Get line items for a grading period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the grading period",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. class.sourcedId='\u003cid\u003e' AND dueDate\u003c'2025-06-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.LineItem"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lineItems": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all line items, optionally scoped to a class and/or grading period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Line Items"
+                ],
+                "summary": "Get all line items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. class.sourcedId='\u003cid\u003e' AND dueDate\u003c'2025-06-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return line items for the class with this sourcedId",
+                        "name": "classSourcedId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return line items in the grading period with this sourcedId",
+                        "name": "gradingPeriodSourcedId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.LineItem"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lineItems/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single line item by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Line Items"
+                ],
+                "summary": "Get a specific line item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the line item",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.LineItem"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all organizations, including schools and districts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Get all organizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='school'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orgs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single organization by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Get a specific organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the organization",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Org"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/{id}/descendants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every organization below the given one, following children references recursively (breadth first). The organization itself is not included; a leaf returns an empty list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orgs"
+                ],
+                "summary": "Get the descendants of an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the organization",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='school'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Org"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/resources": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all learning resources, optionally only those meant for a role and/or of an importance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Get all resources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. importance='primary'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return resources whose roles include this one",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return resources of this importance: primary or secondary",
+                        "name": "importance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Resource"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single learning resource by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resources"
+                ],
+                "summary": "Get a specific resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the resource",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Resource"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all results, ordered by scoreDate by default, optionally only those with a given score status. Use limit and offset to page through them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Results"
+                ],
+                "summary": "Get all results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score\u003e='90'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return results with this score status (exempt, fully graded, not submitted, partially graded or submitted)",
+                        "name": "scoreStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to lineItem to embed the line item of each result under lineItemObject",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Result"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/results/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single result by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Results"
+                ],
+                "summary": "Get a specific result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the result",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Result"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schools": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all organizations with type 'school'.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schools"
+                ],
+                "summary": "Get all schools",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='school'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Org"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single school by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schools"
+                ],
+                "summary": "Get a specific school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the school",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.Org"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/classes": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upserts a batch of up to 1000 classes belonging to the school. Classes without a sourcedId are created\nwith a generated one; classes whose sourcedId exists are replaced. Each class must reference an existing\ncourse and academic sessions and may not belong to another school. The response reports the outcome of\nevery class in request order; invalid classes fail individually without affecting the rest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schools"
+                ],
+                "summary": "Create or replace classes for a school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the school",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Classes to write",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SchoolClassesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.WriteResult"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/students": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the users holding the role 'student' whose orgs include the school.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schools"
+                ],
+                "summary": "Get students for a school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the school",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/schools/{id}/teachers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the users holding the role 'teacher' whose orgs include the school.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schools"
+                ],
+                "summary": "Get teachers for a school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the school",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all users holding the role 'student', including those whose primary role is another.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get all students",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users belonging to the org with this sourcedId, through any of their orgs",
+                        "name": "orgSourcedId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single student by their sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get a specific student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.User"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/classes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of the classes a given student is enrolled in, optionally only those in one term.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Classes"
+                ],
+                "summary": "Get classes for a student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. course.sourcedId='\u003cid\u003e' AND classType='scheduled'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return classes in the term with this sourcedId",
+                        "name": "termSourcedId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Class"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/classmates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the distinct students who share at least one class with a given student, the student excluded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get classmates for a student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of results for a given student, ordered by scoreDate by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Results"
+                ],
+                "summary": "Get results for a student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score\u003e='90'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to lineItem to embed the line item of each result under lineItemObject",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Result"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/transcript": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a student with every enrollment, each with its class, the class's course and the student's results in the class with their line items, all resolved in one object.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get a student's transcript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the student",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.Transcript"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all users holding the role 'teacher', including those whose primary role is another.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get all teachers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users belonging to the org with this sourcedId, through any of their orgs",
+                        "name": "orgSourcedId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single teacher by their sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get a specific teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the teacher",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.User"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}/classes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of the classes a given teacher is enrolled in as a teacher, optionally only those in one term.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Classes"
+                ],
+                "summary": "Get classes for a teacher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the teacher",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. course.sourcedId='\u003cid\u003e' AND classType='scheduled'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return classes in the term with this sourcedId",
+                        "name": "termSourcedId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Class"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/terms": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a collection of all academic sessions with type 'term'.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Sessions"
+                ],
+                "summary": "Get all terms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. type='term' AND startDate\u003e='2025-01-01'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.AcademicSession"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a single term by its sourcedId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Sessions"
+                ],
+                "summary": "Get a specific term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the term",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/main.AcademicSession"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/classes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the classes whose terms reference the term or one of its grading periods.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Get classes for a term",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SourcedId of the term",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. course.sourcedId='\u003cid\u003e' AND classType='scheduled'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Class"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/main.Org"
-                                }
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/schools/{id}": {
+        "/users": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single school by its sourcedId.",
+                "description": "Retrieves a collection of all users, including students and teachers, optionally only those holding a role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Schools"
+                    "Users"
                 ],
-                "summary": "Get a specific school",
+                "summary": "Get all users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the school",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='\u003cid\u003e'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users holding this role, as primary role or otherwise; a comma-separated list matches users holding any of them",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return users belonging to the org with this sourcedId, through any of their orgs",
+                        "name": "orgSourcedId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -573,12 +5664,15 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "$ref": "#/definitions/main.Org"
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.User"
+                                }
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -589,21 +5683,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/students": {
-            "get": {
+        "/users/lookup": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all users with the role 'student'.",
+                "description": "Resolves up to 1000 user sourcedIds in one request. Unknown ids are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Users"
+                ],
+                "summary": "Look up users by sourcedId",
+                "parameters": [
+                    {
+                        "description": "SourcedIds to resolve",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.LookupRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all students",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -616,29 +5724,38 @@ const docTemplate = `{
                                 }
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/students/{id}": {
+        "/users/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single student by their sourcedId.",
+                "description": "Retrieves a single user by their sourcedId.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Users"
                 ],
-                "summary": "Get a specific student",
+                "summary": "Get a specific user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the student",
+                        "description": "SourcedId of the user",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -664,61 +5781,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teachers": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a collection of all users with the role 'teacher'.",
-                "produces": [
+                "description": "Merges the fields present in the body into the user, as a JSON merge patch: omitted fields are left alone and null clears a field. dateLastModified is refreshed; sourcedId cannot be changed. The merged user must keep its required fields (username, givenName, familyName, role, orgs) and hold only OneRoster roles.",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Teachers"
-                ],
-                "summary": "Get all teachers",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/main.User"
-                                }
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/teachers/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a single teacher by their sourcedId.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teachers"
+                    "Users"
                 ],
-                "summary": "Get a specific teacher",
+                "summary": "Update part of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the teacher",
+                        "description": "SourcedId of the user",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Fields of the user to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UserPatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user as last read; the update fails with 412 if it has changed since",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -731,6 +5833,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -739,63 +5850,140 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/terms": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a collection of all academic sessions with type 'term'.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Academic Sessions"
-                ],
-                "summary": "Get all terms",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/main.AcademicSession"
-                                }
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/terms/{id}": {
+        "/users/{id}/enrollments": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single term by its sourcedId.",
+                "description": "Retrieves a collection of all enrollments of a given user across all terms, ordered by beginDate by default.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Academic Sessions"
+                    "Enrollments"
                 ],
-                "summary": "Get a specific term",
+                "summary": "Get enrollments for a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "SourcedId of the term",
+                        "description": "SourcedId of the user",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return items with this status (active, tobedeleted or inactive; case-insensitive)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "only"
+                        ],
+                        "type": "string",
+                        "default": "true",
+                        "description": "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them",
+                        "name": "showDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date",
+                        "name": "modifiedSince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OneRoster filter expression, e.g. role='teacher' AND primary='true'",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort direction: asc or desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to false to return a bare JSON array instead of the OneRoster envelope object",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true to return only the sourcedIds of the page, as {\\",
+                        "name": "idsOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header",
+                        "name": "consistentSnapshot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Read this page from the frozen view with this token, ignoring filter and sort",
+                        "name": "snapshotToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return of each item, e.g. sourcedId,familyName",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -804,68 +5992,49 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "$ref": "#/definitions/main.AcademicSession"
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/main.Enrollment"
+                                }
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieves a collection of all users, including students and teachers.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get all users",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/main.User"
-                                }
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/users/{id}/metadata": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a single user by their sourcedId.",
+                "description": "Retrieves only the metadata extension block of a user. Users without metadata return an empty object.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Users"
                 ],
-                "summary": "Get a specific user",
+                "summary": "Get a user's metadata",
                 "parameters": [
                     {
                         "type": "string",
@@ -879,10 +6048,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "$ref": "#/definitions/main.User"
-                            }
+                            "$ref": "#/definitions/main.UserMetadata"
                         }
                     },
                     "404": {
@@ -1033,6 +6199,50 @@ const docTemplate = `{
                 }
             }
         },
+        "main.ClassCapacity": {
+            "description": "The enrollment cap of a class against its current student enrollment.",
+            "type": "object",
+            "properties": {
+                "enrollmentCount": {
+                    "type": "integer"
+                },
+                "maxEnrollment": {
+                    "description": "null when the class has no cap",
+                    "type": "integer"
+                },
+                "overCapacity": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "main.ClassMetadata": {
+            "description": "Vendor extension fields carried in a class's metadata.",
+            "type": "object",
+            "properties": {
+                "deliveryMode": {
+                    "description": "'in-person', 'hybrid', 'online'",
+                    "type": "string"
+                },
+                "gradebookEnabled": {
+                    "type": "boolean"
+                },
+                "maxEnrollment": {
+                    "description": "student seats; 0 means uncapped",
+                    "type": "integer"
+                },
+                "omitted": {
+                    "description": "Omitted lists, by JSON key, the optional fields chaos mode\ndeliberately left out of the class.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "termless": {
+                    "description": "Termless is set in chaos mode on a class deliberately left with an\nempty terms array.",
+                    "type": "boolean"
+                }
+            }
+        },
         "main.Course": {
             "description": "Represents a course in the course catalog.",
             "type": "object",
@@ -1050,6 +6260,13 @@ const docTemplate = `{
                     }
                 },
                 "metadata": {},
+                "prerequisites": {
+                    "description": "Prerequisites are the courses to complete before this one. They are\nan extension to OneRoster.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.GUIDRef"
+                    }
+                },
                 "resources": {
                     "type": "array",
                     "items": {
@@ -1119,6 +6336,42 @@ const docTemplate = `{
                 }
             }
         },
+        "main.EnrollmentRelated": {
+            "description": "An enrollment with its user, class, course, school and terms dereferenced.",
+            "type": "object",
+            "properties": {
+                "class": {
+                    "$ref": "#/definitions/main.Class"
+                },
+                "course": {
+                    "$ref": "#/definitions/main.Course"
+                },
+                "enrollment": {
+                    "$ref": "#/definitions/main.Enrollment"
+                },
+                "school": {
+                    "$ref": "#/definitions/main.Org"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.AcademicSession"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/main.User"
+                }
+            }
+        },
+        "main.EnrollmentRequest": {
+            "description": "An enrollment to create or replace.",
+            "type": "object",
+            "properties": {
+                "enrollment": {
+                    "$ref": "#/definitions/main.Enrollment"
+                }
+            }
+        },
         "main.GUIDRef": {
             "description": "A reference to another OneRoster object.",
             "type": "object",
@@ -1134,6 +6387,61 @@ const docTemplate = `{
                 }
             }
         },
+        "main.LineItem": {
+            "description": "Represents a gradable activity, such as an assignment or exam, within a class.",
+            "type": "object",
+            "properties": {
+                "assignDate": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/main.GUIDRef"
+                },
+                "class": {
+                    "$ref": "#/definitions/main.GUIDRef"
+                },
+                "dateLastModified": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dueDate": {
+                    "type": "string"
+                },
+                "gradingPeriod": {
+                    "$ref": "#/definitions/main.GUIDRef"
+                },
+                "metadata": {},
+                "resultValueMax": {
+                    "type": "number"
+                },
+                "resultValueMin": {
+                    "type": "number"
+                },
+                "sourcedId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.LookupRequest": {
+            "description": "A batch of sourcedIds to resolve in a single request.",
+            "type": "object",
+            "properties": {
+                "sourcedIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "main.Org": {
             "description": "Represents an organization, such as a school or district.",
             "type": "object",
@@ -1155,7 +6463,13 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "parent": {
-                    "$ref": "#/definitions/main.GUIDRef"
+                    "description": "Parent is written as null for a top-level org such as the district,\nso a missing parent field never means \"has no parent\": it would mean\nthe parent was not included in the response.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/main.GUIDRef"
+                        }
+                    ],
+                    "x-nullable": true
                 },
                 "sourcedId": {
                     "type": "string"
@@ -1169,6 +6483,141 @@ const docTemplate = `{
                 }
             }
         },
+        "main.Resource": {
+            "description": "Represents a learning resource referenced by courses and classes.",
+            "type": "object",
+            "properties": {
+                "applicationId": {
+                    "type": "string"
+                },
+                "dateLastModified": {
+                    "type": "string"
+                },
+                "importance": {
+                    "description": "one of resourceImportances",
+                    "type": "string"
+                },
+                "metadata": {},
+                "roles": {
+                    "description": "the user roles it is meant for, from userRoles",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sourcedId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "vendorId": {
+                    "type": "string"
+                },
+                "vendorResourceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Result": {
+            "description": "Represents the score a student achieved on a line item.",
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "dateLastModified": {
+                    "type": "string"
+                },
+                "lineItem": {
+                    "$ref": "#/definitions/main.GUIDRef"
+                },
+                "metadata": {},
+                "score": {
+                    "description": "null unless graded",
+                    "type": "number"
+                },
+                "scoreDate": {
+                    "type": "string"
+                },
+                "scoreStatus": {
+                    "description": "one of scoreStatuses",
+                    "type": "string"
+                },
+                "sourcedId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student": {
+                    "$ref": "#/definitions/main.GUIDRef"
+                }
+            }
+        },
+        "main.SchoolClassesRequest": {
+            "description": "A batch of classes to create or replace within one school.",
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Class"
+                    }
+                }
+            }
+        },
+        "main.Transcript": {
+            "description": "A student with every enrollment, its class and course, and the student's results in the class.",
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.TranscriptClass"
+                    }
+                },
+                "student": {
+                    "$ref": "#/definitions/main.User"
+                }
+            }
+        },
+        "main.TranscriptClass": {
+            "description": "An enrollment of a transcript with its class, course and the student's results.",
+            "type": "object",
+            "properties": {
+                "class": {
+                    "$ref": "#/definitions/main.Class"
+                },
+                "course": {
+                    "$ref": "#/definitions/main.Course"
+                },
+                "enrollment": {
+                    "$ref": "#/definitions/main.Enrollment"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.TranscriptResult"
+                    }
+                }
+            }
+        },
+        "main.TranscriptResult": {
+            "description": "A result with its line item resolved.",
+            "type": "object",
+            "properties": {
+                "lineItem": {
+                    "$ref": "#/definitions/main.LineItem"
+                },
+                "result": {
+                    "$ref": "#/definitions/main.Result"
+                }
+            }
+        },
         "main.User": {
             "description": "Represents a person within the system, such as a student or a teacher.",
             "type": "object",
@@ -1188,6 +6637,13 @@ const docTemplate = `{
                 "givenName": {
                     "type": "string"
                 },
+                "grades": {
+                    "description": "students only: their grade level, e.g. \"10\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "identifier": {
                     "type": "string"
                 },
@@ -1199,9 +6655,16 @@ const docTemplate = `{
                     }
                 },
                 "role": {
-                    "description": "'student', 'teacher'",
+                    "description": "primary role: 'student', 'teacher', 'administrator'",
                     "type": "string"
                 },
+                "roles": {
+                    "description": "every role held, primary first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "sourcedId": {
                     "type": "string"
                 },
@@ -1216,6 +6679,66 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "main.UserMetadata": {
+            "description": "Vendor extension fields carried in a user's metadata.",
+            "type": "object",
+            "properties": {
+                "accommodations": {
+                    "type": "boolean"
+                },
+                "collidesWith": {
+                    "description": "CollidesWith is set in chaos mode on a user deliberately given the\nemail or username of another: it holds that other user's sourcedId.",
+                    "type": "string"
+                },
+                "nameEdgeCase": {
+                    "description": "NameEdgeCase is set in chaos mode on a user deliberately given an\nawkward name: apostrophe, hyphen, emoji, long or whitespace.",
+                    "type": "string"
+                },
+                "orgless": {
+                    "description": "Orgless is set in chaos mode on a user deliberately left with an\nempty orgs array.",
+                    "type": "boolean"
+                },
+                "preferredLanguage": {
+                    "type": "string"
+                },
+                "transferDate": {
+                    "type": "string"
+                },
+                "transferredFrom": {
+                    "description": "TransferredFrom is set on a student who moved to another school\npartway through a term: it holds the sourcedId of the school they\nleft, and TransferDate their first day at the new one.",
+                    "type": "string"
+                }
+            }
+        },
+        "main.UserPatchRequest": {
+            "description": "A partial user: only the fields present are changed.",
+            "type": "object",
+            "properties": {
+                "user": {
+                    "type": "object"
+                }
+            }
+        },
+        "main.WriteResult": {
+            "description": "The outcome of writing one object of a bulk request.",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "position of the object in the request",
+                    "type": "integer"
+                },
+                "sourcedId": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "'created', 'updated', 'failed'",
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
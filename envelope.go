@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// envelope names the keys an entity type is wrapped in: singular for
+// single-object responses such as {"user": {...}} and plural for collections
+// such as {"users": [...]}.
+type envelope struct {
+	singular string
+	plural   string
+}
+
+// The envelope keys of every entity type. Handlers take their keys from here
+// rather than spelling them out, so a type is written under the same keys on
+// every route, typed views such as /teachers included.
+var (
+	orgEnvelope             = envelope{singular: "org", plural: "orgs"}
+	userEnvelope            = envelope{singular: "user", plural: "users"}
+	courseEnvelope          = envelope{singular: "course", plural: "courses"}
+	classEnvelope           = envelope{singular: "class", plural: "classes"}
+	enrollmentEnvelope      = envelope{singular: "enrollment", plural: "enrollments"}
+	academicSessionEnvelope = envelope{singular: "academicSession", plural: "academicSessions"}
+	categoryEnvelope        = envelope{singular: "category", plural: "categories"}
+	lineItemEnvelope        = envelope{singular: "lineItem", plural: "lineItems"}
+	resultEnvelope          = envelope{singular: "result", plural: "results"}
+	resourceEnvelope        = envelope{singular: "resource", plural: "resources"}
+)
+
+// envelopes lists every registered envelope. No two may share a key.
+var envelopes = []envelope{
+	orgEnvelope, userEnvelope, courseEnvelope, classEnvelope, enrollmentEnvelope,
+	academicSessionEnvelope, categoryEnvelope, lineItemEnvelope, resultEnvelope,
+	resourceEnvelope,
+}
+
+// envelopeBySingular returns the registered envelope whose singular key is
+// name, as the entityType of admin requests gives it.
+func envelopeBySingular(name string) (envelope, bool) {
+	for _, e := range envelopes {
+		if e.singular == name {
+			return e, true
+		}
+	}
+	return envelope{}, false
+}
+
+// invalidEntityType is the error message for an entityType that names no
+// registered envelope.
+func invalidEntityType(name string) string {
+	types := make([]string, len(envelopes))
+	for i, e := range envelopes {
+		types[i] = e.singular
+	}
+	return fmt.Sprintf("Invalid entityType %q: must be one of %s", name, strings.Join(types, ", "))
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+// TestEnvelopeKeys checks that every registered envelope has both keys and
+// that no key is used twice, singular or plural.
+func TestEnvelopeKeys(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, e := range envelopes {
+		if e.singular == "" || e.plural == "" {
+			t.Errorf("envelope %+v: empty key", e)
+		}
+		for _, key := range []string{e.singular, e.plural} {
+			if seen[key] {
+				t.Errorf("envelope key %q registered twice", key)
+			}
+			seen[key] = true
+		}
+	}
+}
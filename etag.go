@@ -0,0 +1,43 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// entityTag returns the strong ETag of a record: a digest of its JSON form,
+// so it changes whenever any field does.
+func entityTag(record any) string {
+	data, _ := json.Marshal(record)
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// checkIfMatch evaluates the If-Match precondition of a write to a record
+// whose current ETag is current, or "" if the record doesn't exist yet. It
+// writes 412 Precondition Failed when none of the listed tags matches (the
+// record was changed by someone else since the client read it) and, when
+// required, 428 Precondition Required for an update without If-Match.
+// Creating a record never needs If-Match. It reports whether the write may
+// go ahead.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, current string, required bool) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if required && current != "" {
+			writeError(w, http.StatusPreconditionRequired, "If-Match is required to update this record; send the ETag from GET")
+			return false
+		}
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if current != "" && (tag == "*" || tag == current) {
+			return true
+		}
+	}
+	writeError(w, http.StatusPreconditionFailed, "If-Match does not match the current record; it was modified or doesn't exist")
+	return false
+}
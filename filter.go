@@ -0,0 +1,322 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file implements the OneRoster "filter" query parameter, e.g.
+//
+//	?filter=familyName='User42'
+//	?filter=role='teacher' AND dateLastModified>'2025-01-01'
+//	?filter=course.sourcedId='<id>'
+//
+// A filter is one or more predicates joined by a single logical operator,
+// either AND or OR. Each predicate compares a field, named by its JSON key,
+// against a single-quoted value using one of =, !=, >, >=, <, <= or ~
+// (contains). Dotted paths such as course.sourcedId reach into nested objects
+// like GUIDRefs; for arrays such as terms or orgs a predicate holds when any
+// element satisfies it (for != when no element equals the value). Status
+// values are compared without regard to case.
+
+// predicatePattern matches a single "field op 'value'" predicate.
+var predicatePattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9_.]*)\s*(!=|>=|<=|=|>|<|~)\s*'(.*)'\s*$`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// filterPredicate is a single compiled comparison.
+type filterPredicate struct {
+	path  [][]int // field index of each dotted path segment
+	op    string
+	value string
+	fold  bool // compare case-insensitively, as for status
+	// Parsed forms of value, depending on the type of the field compared.
+	number float64
+	time   time.Time
+}
+
+// compiledFilter is a parsed filter expression bound to a model type.
+type compiledFilter struct {
+	predicates []filterPredicate
+	or         bool
+}
+
+// applyFilter returns the items matching the filter expression. An empty
+// expression returns items unchanged. Malformed expressions, unknown fields and
+// values that don't fit the field's type are reported as errors.
+func applyFilter[T any](expr string, items []T) ([]T, error) {
+	if strings.TrimSpace(expr) == "" {
+		return items, nil
+	}
+	f, err := compileFilter(expr, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	var matched []T
+	for _, item := range items {
+		if f.matches(reflect.ValueOf(item)) {
+			matched = append(matched, item)
+		}
+	}
+	return matched, nil
+}
+
+// compileFilter parses expr and resolves its field paths against type t.
+func compileFilter(expr string, t reflect.Type) (*compiledFilter, error) {
+	parts, connector, err := splitLogical(expr)
+	if err != nil {
+		return nil, err
+	}
+	f := &compiledFilter{or: connector == "OR"}
+	for _, part := range parts {
+		m := predicatePattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("invalid filter predicate %q: expected field op 'value'", strings.TrimSpace(part))
+		}
+		p := filterPredicate{op: m[2], value: m[3], fold: m[1] == "status"}
+		leaf, err := resolvePath(t, m[1], &p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter field %w", err)
+		}
+		if err := p.bindValue(m[1], leaf); err != nil {
+			return nil, err
+		}
+		f.predicates = append(f.predicates, p)
+	}
+	return f, nil
+}
+
+// splitLogical splits expr on its AND or OR connectors, ignoring any that
+// appear inside quoted values. Mixing AND and OR is rejected.
+func splitLogical(expr string) ([]string, string, error) {
+	var parts []string
+	connector := ""
+	inQuote := false
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		if expr[i] == '\'' {
+			inQuote = !inQuote
+			continue
+		}
+		if inQuote || expr[i] != ' ' {
+			continue
+		}
+		for _, word := range []string{"AND", "OR"} {
+			token := " " + word + " "
+			if !strings.HasPrefix(expr[i:], token) {
+				continue
+			}
+			if connector != "" && connector != word {
+				return nil, "", fmt.Errorf("invalid filter: AND and OR cannot be combined in one filter")
+			}
+			connector = word
+			parts = append(parts, expr[start:i])
+			start = i + len(token)
+			i = start - 1
+			break
+		}
+	}
+	if inQuote {
+		return nil, "", fmt.Errorf("invalid filter: unterminated quoted value")
+	}
+	return append(parts, expr[start:]), connector, nil
+}
+
+// resolvePath records the field indexes of a dotted path in p and returns the
+// type of the field it ends at.
+func resolvePath(t reflect.Type, field string, p *filterPredicate) (reflect.Type, error) {
+	current := t
+	for i, segment := range strings.Split(field, ".") {
+		current = elemType(current)
+		if current.Kind() != reflect.Struct || current == timeType {
+			return nil, fmt.Errorf("%q: %s has no nested fields", field, strings.Join(strings.Split(field, ".")[:i], "."))
+		}
+		sf, ok := jsonField(current, segment)
+		if !ok {
+			return nil, fmt.Errorf("%q: unknown field %q", field, segment)
+		}
+		p.path = append(p.path, sf.Index)
+		current = sf.Type
+	}
+	return elemType(current), nil
+}
+
+// elemType unwraps pointer and slice types down to the element type.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonField finds the field of struct type t serialized under the JSON key
+// name, including fields promoted from embedded structs such as BaseModel.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for _, sf := range reflect.VisibleFields(t) {
+		if sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if key == name {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// bindValue checks that the predicate's operator and value suit the leaf
+// field type and pre-parses the value for comparison.
+func (p *filterPredicate) bindValue(field string, leaf reflect.Type) error {
+	switch {
+	case leaf == timeType:
+		parsed, err := parseFilterTime(p.value)
+		if err != nil {
+			return fmt.Errorf("invalid filter value for %s: %q is not a date or RFC 3339 timestamp", field, p.value)
+		}
+		p.time = parsed
+	case leaf.Kind() == reflect.String:
+		return nil
+	case leaf.Kind() == reflect.Bool:
+		parsed, err := strconv.ParseBool(p.value)
+		if err != nil {
+			return fmt.Errorf("invalid filter value for %s: %q is not a boolean", field, p.value)
+		}
+		p.value = strconv.FormatBool(parsed)
+		if p.op != "=" && p.op != "!=" {
+			return fmt.Errorf("invalid filter operator %s for boolean field %s", p.op, field)
+		}
+		return nil
+	case leaf.Kind() >= reflect.Int && leaf.Kind() <= reflect.Float64:
+		parsed, err := strconv.ParseFloat(p.value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid filter value for %s: %q is not a number", field, p.value)
+		}
+		p.number = parsed
+	case leaf.Kind() == reflect.Struct:
+		return fmt.Errorf("invalid filter field %q: field is an object; filter on a nested field such as %s.sourcedId", field, field)
+	default:
+		return fmt.Errorf("invalid filter field %q: field is not filterable", field)
+	}
+	if p.op == "~" {
+		return fmt.Errorf("invalid filter operator ~ for non-string field %s", field)
+	}
+	return nil
+}
+
+// parseFilterTime accepts either a full RFC 3339 timestamp or a bare date.
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// matches reports whether the item satisfies the filter.
+func (f *compiledFilter) matches(item reflect.Value) bool {
+	for _, p := range f.predicates {
+		if p.matches(item) == f.or {
+			return f.or
+		}
+	}
+	return !f.or
+}
+
+// matches reports whether the item satisfies the predicate.
+func (p *filterPredicate) matches(item reflect.Value) bool {
+	leaves := collectLeaves(item, p.path)
+	if p.op == "!=" {
+		for _, leaf := range leaves {
+			if p.compare(leaf) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	for _, leaf := range leaves {
+		if p.holds(leaf) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectLeaves walks path from v, fanning out over slices and skipping nil
+// pointers, and returns every value found at the end of the path.
+func collectLeaves(v reflect.Value, path [][]int) []reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem() != reflect.TypeOf(byte(0)) {
+		var leaves []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			leaves = append(leaves, collectLeaves(v.Index(i), path)...)
+		}
+		return leaves
+	}
+	if len(path) == 0 {
+		return []reflect.Value{v}
+	}
+	return collectLeaves(v.FieldByIndex(path[0]), path[1:])
+}
+
+// holds evaluates the predicate's operator against one leaf value.
+func (p *filterPredicate) holds(leaf reflect.Value) bool {
+	if p.op == "~" {
+		return strings.Contains(strings.ToLower(leaf.String()), strings.ToLower(p.value))
+	}
+	c := p.compare(leaf)
+	switch p.op {
+	case "=":
+		return c == 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+// compare orders the leaf value relative to the predicate's value.
+func (p *filterPredicate) compare(leaf reflect.Value) int {
+	switch {
+	case leaf.Type() == timeType:
+		return leaf.Interface().(time.Time).Compare(p.time)
+	case leaf.Kind() == reflect.String && p.fold:
+		return strings.Compare(strings.ToLower(leaf.String()), strings.ToLower(p.value))
+	case leaf.Kind() == reflect.String:
+		return strings.Compare(leaf.String(), p.value)
+	case leaf.Kind() == reflect.Bool:
+		if strconv.FormatBool(leaf.Bool()) == p.value {
+			return 0
+		}
+		return 1
+	case leaf.CanInt():
+		return compareFloat(float64(leaf.Int()), p.number)
+	case leaf.CanUint():
+		return compareFloat(float64(leaf.Uint()), p.number)
+	case leaf.CanFloat():
+		return compareFloat(leaf.Float(), p.number)
+	}
+	return 1
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
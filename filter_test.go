@@ -0,0 +1,109 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyFilter(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	users := []User{
+		{BaseModel: BaseModel{SourcedId: "u1", Status: "active", DateLastModified: modified}, GivenName: "Ann", FamilyName: "O'Brien", Role: "student", EnabledUser: true,
+			Orgs: []GUIDRef{{SourcedId: "school-1"}}},
+		{BaseModel: BaseModel{SourcedId: "u2", Status: "tobedeleted", DateLastModified: modified.AddDate(0, 1, 0)}, GivenName: "Bo", FamilyName: "Smith", Role: "teacher",
+			Orgs: []GUIDRef{{SourcedId: "school-1"}, {SourcedId: "school-2"}}},
+		{BaseModel: BaseModel{SourcedId: "u3", Status: "active", DateLastModified: modified.AddDate(0, 2, 0)}, GivenName: "Cy", FamilyName: "Smithson", Role: "student", EnabledUser: true,
+			Orgs: []GUIDRef{{SourcedId: "school-2"}}},
+	}
+	for _, tc := range []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"u1", "u2", "u3"}},
+		{"familyName='Smith'", []string{"u2"}},
+		{"familyName!='Smith'", []string{"u1", "u3"}},
+		{"familyName~'smith'", []string{"u2", "u3"}},
+		{"familyName>'Smith'", []string{"u3"}},
+		{"familyName>='Smith'", []string{"u2", "u3"}},
+		{"familyName<'Smith'", []string{"u1"}},
+		{"familyName<='Smith'", []string{"u1", "u2"}},
+		{"status='ACTIVE'", []string{"u1", "u3"}},
+		{"enabledUser='true'", []string{"u1", "u3"}},
+		{"dateLastModified>'2025-03-15'", []string{"u2", "u3"}},
+		{"dateLastModified<='2025-03-01T12:00:00Z'", []string{"u1"}},
+		{"role='student' AND familyName~'smith'", []string{"u3"}},
+		{"role='teacher' OR givenName='Ann'", []string{"u1", "u2"}},
+		{"role='student' AND familyName~'o' AND givenName='Ann'", []string{"u1"}},
+		// Dotted paths into arrays hold if any element matches, and != if none does.
+		{"orgs.sourcedId='school-2'", []string{"u2", "u3"}},
+		{"orgs.sourcedId!='school-2'", []string{"u1"}},
+		// Connectors inside quoted values are part of the value.
+		{"givenName='Ann AND Bo'", nil},
+		{"  familyName = 'Smith'  ", []string{"u2"}},
+	} {
+		got, err := applyFilter(tc.expr, users)
+		if err != nil {
+			t.Errorf("%s: %v", tc.expr, err)
+			continue
+		}
+		var ids []string
+		for _, user := range got {
+			ids = append(ids, user.SourcedId)
+		}
+		if !slices.Equal(ids, tc.want) {
+			t.Errorf("%s: matched %v, want %v", tc.expr, ids, tc.want)
+		}
+	}
+}
+
+func TestApplyFilterErrors(t *testing.T) {
+	for expr, want := range map[string]string{
+		"familyName=Smith":                       "expected field op 'value'",
+		"familyName=='Smith'":                    "expected field op 'value'",
+		"familyName='Smith":                      "unterminated quoted value",
+		"role='student' AND ":                    "expected field op 'value'",
+		"role='student' AND role='x' OR role=''": "AND and OR cannot be combined",
+		"shoeSize='9'":                           `unknown field "shoeSize"`,
+		"orgs.name='x'":                          `unknown field "name"`,
+		"familyName.first='x'":                   "familyName has no nested fields",
+		"orgs='x'":                               "field is an object",
+		"enabledUser='yes'":                      "not a boolean",
+		"enabledUser>'true'":                     "operator > for boolean field",
+		"dateLastModified>'yesterday'":           "not a date or RFC 3339 timestamp",
+		"dateLastModified~'2025-01-01'":          "operator ~ for non-string field",
+	} {
+		if _, err := applyFilter(expr, []User{}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error %v, want one containing %q", expr, err, want)
+		}
+	}
+	if _, err := applyFilter("score>'high'", []Result{}); err == nil || !strings.Contains(err.Error(), "not a number") {
+		t.Errorf("score>'high': error %v, want not a number", err)
+	}
+}
+
+// TestFilterExamples checks that the filter example documented for each
+// model type is valid for it.
+func TestFilterExamples(t *testing.T) {
+	for _, tc := range []struct {
+		model   any
+		example string
+	}{
+		{Org{}, "type='school'"},
+		{User{}, "familyName='Smith' OR orgs.sourcedId='<id>'"},
+		{Course{}, "title~'Math' AND schoolYear.sourcedId='<id>'"},
+		{Class{}, "course.sourcedId='<id>' AND classType='scheduled'"},
+		{AcademicSession{}, "type='term' AND startDate>='2025-01-01'"},
+		{Category{}, "weight>='20'"},
+		{Resource{}, "importance='primary'"},
+		{LineItem{}, "class.sourcedId='<id>' AND dueDate<'2025-06-01'"},
+		{Result{}, "scoreStatus='fully graded' AND score>='90'"},
+		{Enrollment{}, "role='teacher' AND primary='true'"},
+	} {
+		if _, err := compileFilter(tc.example, reflect.TypeOf(tc.model)); err != nil {
+			t.Errorf("%T: %s: %v", tc.model, tc.example, err)
+		}
+	}
+}
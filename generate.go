@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// GenerateRequest is the body accepted by POST /admin/generate: how many
+// records of each kind to add to the store.
+type GenerateRequest struct {
+	Students    int `json:"students"`
+	Enrollments int `json:"enrollments"`
+}
+
+// GenerateResponse lists the sourcedIds of the records POST /admin/generate
+// added, under their envelope keys.
+type GenerateResponse map[string][]string
+
+// postGenerate handles requests to grow the store without resetting it.
+// New students are spread over the schools like generated ones. New
+// enrollments enroll students, newest first, as students in classes of
+// their school they are not yet in, for the dates of the class's term. Every
+// new record counts as written now. Unknown body fields are refused, so that
+// a misspelt count isn't taken for zero.
+func (h *APIHandlers) postGenerate(w http.ResponseWriter, r *http.Request) {
+	var req GenerateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	for _, count := range []struct {
+		name string
+		n    int
+	}{{"students", req.Students}, {"enrollments", req.Enrollments}} {
+		if count.n < 0 || count.n > maxWriteBatch {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %s count: must be from 0 to %d", count.name, maxWriteBatch))
+			return
+		}
+	}
+
+	ds := h.Store
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	added := GenerateResponse{userEnvelope.plural: {}, enrollmentEnvelope.plural: {}}
+	if req.Students > 0 {
+		schools := slices.DeleteFunc(slices.Clone(ds.Orgs), func(o Org) bool { return o.Type != "school" })
+		if len(schools) == 0 {
+			writeError(w, http.StatusConflict, "The store has no schools to add students to")
+			return
+		}
+		count := 0
+		for _, user := range ds.Users {
+			if user.hasRole("student") {
+				count++
+			}
+		}
+		for i := count + 1; i <= count+req.Students; i++ {
+			student := newStudent(uuid.New().String(), i, schools[i%len(schools)], gradeLevel(i, len(schools)), ds.locale)
+			student.DateLastModified = ds.now()
+			ds.Users = append(ds.Users, student)
+			ds.markWritten(student.SourcedId)
+			added[userEnvelope.plural] = append(added[userEnvelope.plural], student.SourcedId)
+		}
+	}
+	if req.Enrollments > 0 {
+		added[enrollmentEnvelope.plural] = ds.generateEnrollments(req.Enrollments)
+	}
+	writeJSON(w, http.StatusCreated, added)
+}
+
+// generateEnrollments adds up to n student enrollments and returns their
+// sourcedIds. Students are only enrolled in classes of their grade, if they
+// have one. It stops early once every student attends every such class of
+// their school. The caller must hold ds.mu.
+func (ds *DataStore) generateEnrollments(n int) []string {
+	sessions := make(map[string]AcademicSession)
+	for _, session := range ds.AcademicSessions {
+		sessions[session.SourcedId] = session
+	}
+	enrolled := make(map[[2]string]bool)
+	for _, enrollment := range ds.enrollments() {
+		enrolled[[2]string{enrollment.User.SourcedId, enrollment.Class.SourcedId}] = true
+	}
+
+	ids := []string{}
+	for u := len(ds.Users) - 1; u >= 0 && len(ids) < n; u-- {
+		user := ds.Users[u]
+		if !user.hasRole("student") || len(user.Orgs) == 0 {
+			continue
+		}
+		for _, class := range ds.Classes {
+			if len(ids) == n {
+				break
+			}
+			if class.School.SourcedId != user.Orgs[0].SourcedId || enrolled[[2]string{user.SourcedId, class.SourcedId}] {
+				continue
+			}
+			if len(user.Grades) > 0 && !slices.ContainsFunc(class.Grades, func(g string) bool { return slices.Contains(user.Grades, g) }) {
+				continue
+			}
+			enrollment := Enrollment{
+				BaseModel: BaseModel{SourcedId: uuid.New().String(), Status: "active", DateLastModified: ds.now()},
+				User:      GUIDRef{Href: "/users/" + user.SourcedId, SourcedId: user.SourcedId, Type: "user"},
+				Class:     GUIDRef{Href: "/classes/" + class.SourcedId, SourcedId: class.SourcedId, Type: "class"},
+				School:    class.School,
+				Role:      "student",
+			}
+			if len(class.Terms) > 0 {
+				term := sessions[class.Terms[0].SourcedId]
+				enrollment.BeginDate, enrollment.EndDate = dateOnly(term.StartDate), dateOnly(term.EndDate)
+			}
+			ds.Enrollments = append(ds.Enrollments, enrollment)
+			enrolled[[2]string{user.SourcedId, class.SourcedId}] = true
+			ds.markWritten(enrollment.SourcedId)
+			ids = append(ids, enrollment.SourcedId)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPostGenerate(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	users := len(s.store.Users)
+	rec := s.do(t, http.MethodPost, "/admin/generate", `{"students": 3, "enrollments": 2}`, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /admin/generate: %d %s", rec.Code, rec.Body)
+	}
+	added := decode[GenerateResponse](t, rec)
+	if len(added["users"]) != 3 || len(added["enrollments"]) != 2 || len(s.store.Users) != users+3 {
+		t.Errorf("added %v, want 3 users and 2 enrollments", added)
+	}
+
+	for _, body := range []string{`{"teachers": 3}`, `{"students": -1}`, `{"students": "3"}`} {
+		if rec := s.do(t, http.MethodPost, "/admin/generate", body, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("POST /admin/generate %s: %d %s, want 400", body, rec.Code, rec.Body)
+		}
+	}
+	if len(s.store.Users) != users+3 {
+		t.Errorf("refused requests changed the store: %d users, want %d", len(s.store.Users), users+3)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import "net/http"
+
+// GenerationReport is the body returned by GET /admin/genreport.
+type GenerationReport struct {
+	// Source is where the data came from: "generated" or "file".
+	Source string `json:"source"`
+	// Seed is the seed the data was generated from, or null when it was
+	// loaded from MOCK_DATA_FILE.
+	Seed *uint64 `json:"seed"`
+	// Counts holds, per collection and for schools, students and teachers,
+	// how many records the store started with and, for generated data, how
+	// many the configuration asked for.
+	Counts map[string]GenerationCount `json:"counts"`
+	// Warnings are the ignored settings and the shortfalls of generation.
+	Warnings []string `json:"warnings"`
+	// IntegrityViolations are those ValidateStore found at startup.
+	IntegrityViolations []string `json:"integrityViolations"`
+	// DeliberateInconsistencies are the violations found on records chaos
+	// mode made inconsistent on purpose, which don't count as
+	// IntegrityViolations.
+	DeliberateInconsistencies []string `json:"deliberateInconsistencies"`
+}
+
+// GenerationCount compares the records of one kind asked for and made.
+type GenerationCount struct {
+	Requested *int `json:"requested,omitempty"`
+	Generated int  `json:"generated"`
+}
+
+// newGenerationReport describes the state of store as generated or loaded
+// under cfg. It must be called before the store serves any request, so that
+// later writes don't show up in the counts.
+func newGenerationReport(store *DataStore, cfg Config) GenerationReport {
+	report := GenerationReport{
+		Source:                    "file",
+		Counts:                    make(map[string]GenerationCount),
+		Warnings:                  append(append([]string{}, cfg.Warnings...), store.warnings...),
+		IntegrityViolations:       []string{},
+		DeliberateInconsistencies: []string{},
+	}
+	for collection, n := range entityCounts(store) {
+		report.Counts[collection] = GenerationCount{Generated: n}
+	}
+	var schools, students, teachers int
+	for _, org := range store.Orgs {
+		if org.Type == "school" {
+			schools++
+		}
+	}
+	for _, user := range store.Users {
+		if user.hasRole("student") {
+			students++
+		}
+		if user.hasRole("teacher") {
+			teachers++
+		}
+	}
+	report.Counts["schools"] = GenerationCount{Generated: schools}
+	report.Counts["students"] = GenerationCount{Generated: students}
+	report.Counts["teachers"] = GenerationCount{Generated: teachers}
+
+	if store.seed != 0 {
+		report.Source = "generated"
+		report.Seed = &store.seed
+		for key, requested := range map[string]int{
+			"schools":             cfg.Schools,
+			"students":            cfg.Students,
+			"teachers":            cfg.Teachers,
+			courseEnvelope.plural: cfg.Courses,
+			classEnvelope.plural:  cfg.Classes,
+		} {
+			count := report.Counts[key]
+			count.Requested = &requested
+			report.Counts[key] = count
+		}
+	}
+	violations, deliberate := checkStore(store)
+	for _, violation := range violations {
+		report.IntegrityViolations = append(report.IntegrityViolations, violation.Error())
+	}
+	for _, inconsistency := range deliberate {
+		report.DeliberateInconsistencies = append(report.DeliberateInconsistencies, inconsistency.Error())
+	}
+	return report
+}
+
+// genReport returns the handler of GET /admin/genreport, which serves
+// report so that a remote test harness can check the dataset came out as
+// expected, e.g. that enrollment generation produced any enrollments.
+func genReport(report GenerationReport) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, report)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import "fmt"
+
+// goldenSeed is the seed of the golden dataset.
+const goldenSeed = 1
+
+// applyGoldenProfile sets cfg up for MOCK_PROFILE=golden: a dataset small
+// enough to check by hand, for documentation examples and for tests that
+// assert exact responses. It is generated like any other, under goldenSeed,
+// so its sourcedIds never change:
+//
+//	district  51ff9588-cdca-5821-a225-fcf8c5650348  District #1 (DST001)
+//	school    3eaf3a54-097f-5eec-aae0-e77848247474  School #1 (SCH001)
+//	school    f6d4ff49-f0b1-52a6-8a5a-f3902333b597  School #2 (SCH002)
+//	student   d7390735-4a1a-559c-abe3-6127fbad0a68  student1, School #2, grade 09
+//	student   ccd52f0b-246f-520a-bb48-3f35b6604c98  student2, School #1, grade 10
+//	student   31f05976-b11e-54ed-ad76-e18c8f8894c1  student3, School #2, grade 10
+//	student   a19cb03c-77c8-5f97-b593-b07e281b5ea1  student4, School #1, grade 11
+//	teacher   f009fadb-64b3-53ba-aad4-2cdc394d3fda  teacher1, School #2
+//	teacher   77a4c6e3-edd2-57ac-a8a7-499fdd5c1a37  teacher2, School #1
+//	course    d6c262b7-00ad-5551-8314-fa36ea575888  Course 1 (CRS001)
+//	course    79113897-42fd-57f8-ae07-da0427f87d15  Course 2 (CRS002)
+//	course    064eb863-9e8f-5c3a-af99-8769a93cdf90  Course 3 (CRS003)
+//	class     07630f06-c8d2-5be2-ae06-36cfe7db6c7b  CRS002-S1, School #2, teacher1 and student1
+//	class     0ee09ef6-857e-5cdc-b440-4bdbf2d270d2  CRS003-S2, School #1, teacher2 and student2
+//	class     a7d37b08-4f1b-5705-891e-d8b57785b02c  CRS001-S3, School #2, teacher1 and student3
+//	class     d66f1028-e984-5cf0-ac85-1098b664555a  CRS002-S4, School #1, teacher2 and student4
+//
+// Each class thus has two enrollments, eight in all; student3's starts late
+// and student4's ends early. The ids of the other records follow from their
+// keys as GeneratedID describes. Dates are relative to the day the server
+// starts, so they are not fixed; neither are names under a MOCK_LOCALE other
+// than en, nor anything MOCK_CHAOS or MOCK_COHORT_OVERLAP changes. The seed
+// and counts can't be set alongside the profile, and neither can
+// MOCK_DATA_FILE.
+func applyGoldenProfile(cfg *Config, getenv func(string) string) []string {
+	var errs []string
+	for _, name := range []string{"MOCK_DATA_FILE", "MOCK_SEED", "MOCK_SCHOOLS", "MOCK_STUDENTS", "MOCK_TEACHERS", "MOCK_COURSES", "MOCK_CLASSES"} {
+		if getenv(name) != "" {
+			errs = append(errs, fmt.Sprintf("%s cannot be combined with MOCK_PROFILE=golden", name))
+		}
+	}
+	cfg.Seed = goldenSeed
+	cfg.Schools, cfg.Students, cfg.Teachers, cfg.Courses, cfg.Classes = 2, 4, 2, 3, 4
+	return errs
+}
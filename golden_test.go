@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestGoldenProfile pins the counts and some of the ids documented on
+// applyGoldenProfile.
+func TestGoldenProfile(t *testing.T) {
+	cfg, err := loadConfig(func(key string) string {
+		if key == "MOCK_PROFILE" {
+			return "golden"
+		}
+		return ""
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := NewDataStore(cfg)
+	if got := []int{len(ds.Orgs), len(ds.Users), len(ds.Courses), len(ds.Classes), len(ds.enrollments())}; !slices.Equal(got, []int{3, 6, 3, 4, 8}) {
+		t.Errorf("orgs, users, courses, classes and enrollments: %v, want [3 6 3 4 8]", got)
+	}
+
+	if district := find(ds.Orgs, "51ff9588-cdca-5821-a225-fcf8c5650348"); district == nil || district.Identifier != "DST001" {
+		t.Errorf("district: %+v, want DST001", district)
+	}
+	school2 := "f6d4ff49-f0b1-52a6-8a5a-f3902333b597"
+	if school := find(ds.Orgs, school2); school == nil || school.Identifier != "SCH002" {
+		t.Errorf("School #2: %+v, want SCH002", school)
+	}
+	student1, teacher1 := find(ds.Users, "d7390735-4a1a-559c-abe3-6127fbad0a68"), find(ds.Users, "f009fadb-64b3-53ba-aad4-2cdc394d3fda")
+	if student1 == nil || student1.Username != "student1" || student1.Orgs[0].SourcedId != school2 || !slices.Equal(student1.Grades, []string{"09"}) {
+		t.Errorf("student1: %+v, want student1 of School #2 in grade 09", student1)
+	}
+	if teacher1 == nil || teacher1.Username != "teacher1" || teacher1.Orgs[0].SourcedId != school2 {
+		t.Errorf("teacher1: %+v, want teacher1 of School #2", teacher1)
+	}
+	if course := find(ds.Courses, "d6c262b7-00ad-5551-8314-fa36ea575888"); course == nil || course.CourseCode != "CRS001" {
+		t.Errorf("Course 1: %+v, want CRS001", course)
+	}
+
+	classId := "07630f06-c8d2-5be2-ae06-36cfe7db6c7b"
+	class := find(ds.Classes, classId)
+	if class == nil || class.ClassCode != "CRS002-S1" || class.School.SourcedId != school2 {
+		t.Fatalf("class %s: %+v, want CRS002-S1 of School #2", classId, class)
+	}
+	var enrolled []string
+	for _, enrollment := range ds.enrollmentsOfClass(classId) {
+		enrolled = append(enrolled, enrollment.User.SourcedId)
+	}
+	slices.Sort(enrolled)
+	want := []string{student1.SourcedId, teacher1.SourcedId}
+	slices.Sort(want)
+	if !slices.Equal(enrolled, want) {
+		t.Errorf("class %s enrolls %v, want teacher1 and student1", classId, enrolled)
+	}
+}
+
+func TestGoldenProfileConflicts(t *testing.T) {
+	_, err := loadConfig(func(key string) string {
+		return map[string]string{"MOCK_PROFILE": "golden", "MOCK_STUDENTS": "40"}[key]
+	})
+	if err == nil {
+		t.Error("MOCK_PROFILE=golden with MOCK_STUDENTS: accepted")
+	}
+}
@@ -3,6 +3,10 @@ package main
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -10,6 +14,9 @@ import (
 // APIHandlers holds a reference to our in-memory data store.
 type APIHandlers struct {
 	Store *DataStore
+	// RequireIfMatch makes single-record updates fail with 428 without an
+	// If-Match header (MOCK_REQUIRE_IF_MATCH).
+	RequireIfMatch bool
 }
 
 // writeJSON is a helper to serialize data to JSON and write the HTTP response.
@@ -19,15 +26,155 @@ func writeJSON(w http.ResponseWriter, status int, data any) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// writeError writes an error response with the given status and message.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// StatusInfo is the OneRoster imsx_StatusInfo error payload, used where a
+// client is expected to handle the error the way it would a real provider's.
+// @Description A OneRoster status report describing why a request failed.
+type StatusInfo struct {
+	CodeMajor   string    `json:"imsx_codeMajor"`
+	Severity    string    `json:"imsx_severity"`
+	Description string    `json:"imsx_description"`
+	CodeMinor   CodeMinor `json:"imsx_CodeMinor"`
+}
+
+// CodeMinor holds the OneRoster minor status codes of a StatusInfo.
+type CodeMinor struct {
+	Fields []CodeMinorField `json:"imsx_codeMinorField"`
+}
+
+// CodeMinorField is a single OneRoster minor status code.
+type CodeMinorField struct {
+	Name  string `json:"imsx_codeMinorFieldName"`
+	Value string `json:"imsx_codeMinorFieldValue"`
+}
+
+// writeStatusInfo writes a OneRoster failure response with the given minor
+// code (e.g. "server_busy", "unauthorisedrequest") and description.
+func writeStatusInfo(w http.ResponseWriter, status int, codeMinor, description string) {
+	writeJSON(w, status, StatusInfo{
+		CodeMajor:   "failure",
+		Severity:    "error",
+		Description: description,
+		CodeMinor:   CodeMinor{Fields: []CodeMinorField{{Name: "TargetEndSystem", Value: codeMinor}}},
+	})
+}
+
+// typedView is a read-only view over the elements of a store collection that
+// satisfy a type predicate, such as the schools among all orgs. It serves the
+// collection and single-object routes for that subset, so adding a new typed
+// route only needs a new row in one of the view constructors below.
+type typedView[T entity] struct {
+	items    []T
+	match    func(T) bool
+	envelope envelope
+	notFound string
+	// etag makes get send the ETag of the element, for collections whose
+	// writes take If-Match.
+	etag bool
+}
+
+// list writes every matching element under the plural envelope key.
+func (v typedView[T]) list(w http.ResponseWriter, r *http.Request) {
+	var matched []T
+	for _, item := range v.items {
+		if v.match(item) {
+			matched = append(matched, item)
+		}
+	}
+	writeCollection(w, r, v.envelope.plural, matched)
+}
+
+// get writes the matching element identified by the {id} path parameter, or a
+// 404 if no matching element has that SourcedId.
+func (v typedView[T]) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	for _, item := range v.items {
+		if item.sourcedID() == id && v.match(item) {
+			if v.etag {
+				w.Header().Set("ETag", entityTag(item))
+			}
+			writeJSON(w, http.StatusOK, map[string]T{v.envelope.singular: item})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, v.notFound)
+}
+
+// writeMetadata writes the bare metadata block of the item identified by the
+// {id} path parameter, or an empty object when it has none.
+func writeMetadata[T entity](w http.ResponseWriter, r *http.Request, items []T, notFound string) {
+	id := chi.URLParam(r, "id")
+	for _, item := range items {
+		if item.sourcedID() == id {
+			metadata := item.metadata()
+			if metadata == nil {
+				metadata = map[string]any{}
+			}
+			writeJSON(w, http.StatusOK, metadata)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, notFound)
+}
+
+// orgView returns the view over orgs of the given type.
+func (h *APIHandlers) orgView(orgType, notFound string) typedView[Org] {
+	return typedView[Org]{
+		items:    visibleOnly(h.Store, h.Store.Orgs),
+		match:    func(o Org) bool { return o.Type == orgType },
+		envelope: orgEnvelope,
+		notFound: notFound,
+	}
+}
+
+// userView returns the view over users with the given role.
+func (h *APIHandlers) userView(role, notFound string) typedView[User] {
+	return typedView[User]{
+		items:    visibleOnly(h.Store, h.Store.Users),
+		match:    func(u User) bool { return u.hasRole(role) },
+		envelope: userEnvelope,
+		notFound: notFound,
+		etag:     true,
+	}
+}
+
+// sessionView returns the view over academic sessions of the given type.
+func (h *APIHandlers) sessionView(sessionType, notFound string) typedView[AcademicSession] {
+	return typedView[AcademicSession]{
+		items:    visibleOnly(h.Store, h.Store.AcademicSessions),
+		match:    func(s AcademicSession) bool { return s.Type == sessionType },
+		envelope: academicSessionEnvelope,
+		notFound: notFound,
+	}
+}
+
 // getOrgs handles requests for all organizations.
 // @Summary Get all organizations
 // @Description Retrieves a collection of all organizations, including schools and districts.
 // @Tags Orgs
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='school'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Security ApiKeyAuth
 // @Router /orgs [get]
 func (h *APIHandlers) getOrgs(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]Org{"orgs": h.Store.Orgs})
+	writeCollection(w, r, orgEnvelope.plural, visibleOnly(h.Store, h.Store.Orgs))
 }
 
 // getOrg handles requests for a single organization by its SourcedId.
@@ -42,13 +189,67 @@ func (h *APIHandlers) getOrgs(w http.ResponseWriter, r *http.Request) {
 // @Router /orgs/{id} [get]
 func (h *APIHandlers) getOrg(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, org := range h.Store.Orgs {
+	for _, org := range visibleOnly(h.Store, h.Store.Orgs) {
 		if org.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]Org{"org": org})
+			writeJSON(w, http.StatusOK, map[string]Org{orgEnvelope.singular: org})
 			return
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Org not found"})
+	writeError(w, http.StatusNotFound, "Org not found")
+}
+
+// getOrgDescendants handles requests for every org beneath an org.
+// @Summary Get the descendants of an organization
+// @Description Retrieves every organization below the given one, following children references recursively (breadth first). The organization itself is not included; a leaf returns an empty list.
+// @Tags Orgs
+// @Produce json
+// @Param id path string true "SourcedId of the organization"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='school'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Org
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/descendants [get]
+func (h *APIHandlers) getOrgDescendants(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	orgs := make(map[string]Org)
+	for _, org := range visibleOnly(h.Store, h.Store.Orgs) {
+		orgs[org.SourcedId] = org
+	}
+	root, ok := orgs[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Org not found")
+		return
+	}
+	// Walk breadth first, visiting each org once so a cycle in the
+	// hierarchy can't loop forever or list an org twice.
+	visited := map[string]bool{id: true}
+	queue := root.Children
+	var descendants []Org
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		child, ok := orgs[ref.SourcedId]
+		if !ok || visited[ref.SourcedId] {
+			continue
+		}
+		visited[ref.SourcedId] = true
+		descendants = append(descendants, child)
+		queue = append(queue, child.Children...)
+	}
+	writeCollection(w, r, orgEnvelope.plural, descendants)
 }
 
 // getSchools handles requests for organizations of type 'school'.
@@ -56,17 +257,25 @@ func (h *APIHandlers) getOrg(w http.ResponseWriter, r *http.Request) {
 // @Description Retrieves a collection of all organizations with type 'school'.
 // @Tags Schools
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='school'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Success 200 {object} map[string][]Org
 // @Security ApiKeyAuth
 // @Router /schools [get]
 func (h *APIHandlers) getSchools(w http.ResponseWriter, r *http.Request) {
-	var schools []Org
-	for _, org := range h.Store.Orgs {
-		if org.Type == "school" {
-			schools = append(schools, org)
-		}
-	}
-	writeJSON(w, http.StatusOK, map[string][]Org{"orgs": schools})
+	h.orgView("school", "School not found").list(w, r)
 }
 
 // getSchool handles requests for a single school by its SourcedId.
@@ -80,26 +289,134 @@ func (h *APIHandlers) getSchools(w http.ResponseWriter, r *http.Request) {
 // @Security ApiKeyAuth
 // @Router /schools/{id} [get]
 func (h *APIHandlers) getSchool(w http.ResponseWriter, r *http.Request) {
+	h.orgView("school", "School not found").get(w, r)
+}
+
+// getTeachersForSchool handles requests for the teachers of a school.
+// @Summary Get teachers for a school
+// @Description Retrieves the users holding the role 'teacher' whose orgs include the school.
+// @Tags Schools
+// @Produce json
+// @Param id path string true "SourcedId of the school"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]User
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /schools/{id}/teachers [get]
+func (h *APIHandlers) getTeachersForSchool(w http.ResponseWriter, r *http.Request) {
+	h.writeSchoolUsers(w, r, "teacher")
+}
+
+// getStudentsForSchool handles requests for the students of a school.
+// @Summary Get students for a school
+// @Description Retrieves the users holding the role 'student' whose orgs include the school.
+// @Tags Schools
+// @Produce json
+// @Param id path string true "SourcedId of the school"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]User
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /schools/{id}/students [get]
+func (h *APIHandlers) getStudentsForSchool(w http.ResponseWriter, r *http.Request) {
+	h.writeSchoolUsers(w, r, "student")
+}
+
+// writeSchoolUsers writes the users holding role that belong to the school
+// named by the {id} path parameter.
+func (h *APIHandlers) writeSchoolUsers(w http.ResponseWriter, r *http.Request, role string) {
 	id := chi.URLParam(r, "id")
-	for _, org := range h.Store.Orgs {
-		if org.SourcedId == id && org.Type == "school" {
-			writeJSON(w, http.StatusOK, map[string]Org{"org": org})
-			return
+	if !slices.ContainsFunc(visibleOnly(h.Store, h.Store.Orgs), func(o Org) bool { return o.SourcedId == id && o.Type == "school" }) {
+		writeError(w, http.StatusNotFound, "School not found")
+		return
+	}
+	var users []User
+	for _, user := range visibleOnly(h.Store, h.Store.Users) {
+		if user.hasRole(role) && slices.ContainsFunc(user.Orgs, func(o GUIDRef) bool { return o.SourcedId == id }) {
+			users = append(users, user)
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "School not found"})
+	writeCollection(w, r, userEnvelope.plural, users)
 }
 
 // getUsers handles requests for all users.
 // @Summary Get all users
-// @Description Retrieves a collection of all users, including students and teachers.
+// @Description Retrieves a collection of all users, including students and teachers, optionally only those holding a role.
 // @Tags Users
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param role query string false "Only return users holding this role, as primary role or otherwise; a comma-separated list matches users holding any of them"
+// @Param orgSourcedId query string false "Only return users belonging to the org with this sourcedId, through any of their orgs"
 // @Success 200 {object} map[string][]User
+// @Failure 400 {object} map[string]string
 // @Security ApiKeyAuth
 // @Router /users [get]
 func (h *APIHandlers) getUsers(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]User{"users": h.Store.Users})
+	users := withOrg(r, visibleOnly(h.Store, h.Store.Users))
+	if value := r.URL.Query().Get("role"); value != "" {
+		roles := strings.Split(value, ",")
+		for _, role := range roles {
+			if !slices.Contains(userRoles, role) {
+				writeError(w, http.StatusBadRequest, "Invalid role "+strconv.Quote(role)+": must be one of "+strings.Join(userRoles, ", "))
+				return
+			}
+		}
+		users = slices.DeleteFunc(slices.Clone(users), func(u User) bool {
+			return !slices.ContainsFunc(roles, u.hasRole)
+		})
+	}
+	writeCollection(w, r, userEnvelope.plural, users)
+}
+
+// withOrg returns the users belonging to the org named by the orgSourcedId
+// query parameter, through any of their orgs, or all users without it.
+func withOrg(r *http.Request, users []User) []User {
+	if !r.URL.Query().Has("orgSourcedId") {
+		return users
+	}
+	orgId := r.URL.Query().Get("orgSourcedId")
+	return slices.DeleteFunc(slices.Clone(users), func(u User) bool {
+		return !slices.ContainsFunc(u.Orgs, func(o GUIDRef) bool { return o.SourcedId == orgId })
+	})
 }
 
 // getUser handles requests for a single user by SourcedId.
@@ -114,31 +431,57 @@ func (h *APIHandlers) getUsers(w http.ResponseWriter, r *http.Request) {
 // @Router /users/{id} [get]
 func (h *APIHandlers) getUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, user := range h.Store.Users {
+	for _, user := range visibleOnly(h.Store, h.Store.Users) {
 		if user.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]User{"user": user})
+			w.Header().Set("ETag", entityTag(user))
+			writeJSON(w, http.StatusOK, map[string]User{userEnvelope.singular: user})
 			return
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "User not found"})
+	writeError(w, http.StatusNotFound, "User not found")
+}
+
+// getUserMetadata handles requests for just the metadata block of a user.
+// @Summary Get a user's metadata
+// @Description Retrieves only the metadata extension block of a user. Users without metadata return an empty object.
+// @Tags Users
+// @Produce json
+// @Param id path string true "SourcedId of the user"
+// @Success 200 {object} UserMetadata
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /users/{id}/metadata [get]
+func (h *APIHandlers) getUserMetadata(w http.ResponseWriter, r *http.Request) {
+	writeMetadata(w, r, visibleOnly(h.Store, h.Store.Users), "User not found")
 }
 
 // getTeachers handles requests for users with role 'teacher'.
 // @Summary Get all teachers
-// @Description Retrieves a collection of all users with the role 'teacher'.
+// @Description Retrieves a collection of all users holding the role 'teacher', including those whose primary role is another.
 // @Tags Teachers
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param orgSourcedId query string false "Only return users belonging to the org with this sourcedId, through any of their orgs"
 // @Success 200 {object} map[string][]User
 // @Security ApiKeyAuth
 // @Router /teachers [get]
 func (h *APIHandlers) getTeachers(w http.ResponseWriter, r *http.Request) {
-	var teachers []User
-	for _, user := range h.Store.Users {
-		if user.Role == "teacher" {
-			teachers = append(teachers, user)
-		}
-	}
-	writeJSON(w, http.StatusOK, map[string][]User{"users": teachers})
+	view := h.userView("teacher", "Teacher not found")
+	view.items = withOrg(r, view.items)
+	view.list(w, r)
 }
 
 // getTeacher handles requests for a single teacher by SourcedId.
@@ -152,32 +495,36 @@ func (h *APIHandlers) getTeachers(w http.ResponseWriter, r *http.Request) {
 // @Security ApiKeyAuth
 // @Router /teachers/{id} [get]
 func (h *APIHandlers) getTeacher(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	for _, user := range h.Store.Users {
-		if user.SourcedId == id && user.Role == "teacher" {
-			writeJSON(w, http.StatusOK, map[string]User{"user": user})
-			return
-		}
-	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Teacher not found"})
+	h.userView("teacher", "Teacher not found").get(w, r)
 }
 
 // getStudents handles requests for users with role 'student'.
 // @Summary Get all students
-// @Description Retrieves a collection of all users with the role 'student'.
+// @Description Retrieves a collection of all users holding the role 'student', including those whose primary role is another.
 // @Tags Students
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param orgSourcedId query string false "Only return users belonging to the org with this sourcedId, through any of their orgs"
 // @Success 200 {object} map[string][]User
 // @Security ApiKeyAuth
 // @Router /students [get]
 func (h *APIHandlers) getStudents(w http.ResponseWriter, r *http.Request) {
-	var students []User
-	for _, user := range h.Store.Users {
-		if user.Role == "student" {
-			students = append(students, user)
-		}
-	}
-	writeJSON(w, http.StatusOK, map[string][]User{"users": students})
+	view := h.userView("student", "Student not found")
+	view.items = withOrg(r, view.items)
+	view.list(w, r)
 }
 
 // getStudent handles requests for a single student by SourcedId.
@@ -191,26 +538,54 @@ func (h *APIHandlers) getStudents(w http.ResponseWriter, r *http.Request) {
 // @Security ApiKeyAuth
 // @Router /students/{id} [get]
 func (h *APIHandlers) getStudent(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	for _, user := range h.Store.Users {
-		if user.SourcedId == id && user.Role == "student" {
-			writeJSON(w, http.StatusOK, map[string]User{"user": user})
-			return
-		}
-	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Student not found"})
+	h.userView("student", "Student not found").get(w, r)
 }
 
 // getCourses handles requests for all courses.
 // @Summary Get all courses
-// @Description Retrieves a collection of all courses from the catalog.
+// @Description Retrieves a collection of all courses from the catalog, optionally limited to one school year and/or to those matching a search.
 // @Tags Courses
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. title~'Math' AND schoolYear.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param schoolYear query string false "Only return courses in the schoolYear academic session with this sourcedId"
+// @Param search query string false "Only return courses whose title, courseCode or a subject contains this text, case-insensitively"
 // @Success 200 {object} map[string][]Course
 // @Security ApiKeyAuth
 // @Router /courses [get]
 func (h *APIHandlers) getCourses(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]Course{"courses": h.Store.Courses})
+	schoolYear, search := r.URL.Query().Get("schoolYear"), r.URL.Query().Get("search")
+	courses := visibleOnly(h.Store, h.Store.Courses)
+	if schoolYear != "" || search != "" {
+		courses = slices.DeleteFunc(slices.Clone(courses), func(c Course) bool {
+			return schoolYear != "" && (c.SchoolYear == nil || c.SchoolYear.SourcedId != schoolYear) ||
+				!matchesSearch(search, append([]string{c.Title, c.CourseCode}, c.Subjects...))
+		})
+	}
+	writeCollection(w, r, courseEnvelope.plural, courses)
+}
+
+// matchesSearch reports whether any of values contains search, ignoring
+// case, as the search parameter of /courses and /classes asks. An empty
+// search matches everything.
+func matchesSearch(search string, values []string) bool {
+	if search == "" {
+		return true
+	}
+	search = strings.ToLower(search)
+	return slices.ContainsFunc(values, func(value string) bool { return strings.Contains(strings.ToLower(value), search) })
 }
 
 // getCourse handles requests for a single course by SourcedId.
@@ -225,25 +600,111 @@ func (h *APIHandlers) getCourses(w http.ResponseWriter, r *http.Request) {
 // @Router /courses/{id} [get]
 func (h *APIHandlers) getCourse(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, course := range h.Store.Courses {
+	for _, course := range visibleOnly(h.Store, h.Store.Courses) {
 		if course.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]Course{"course": course})
+			writeJSON(w, http.StatusOK, map[string]Course{courseEnvelope.singular: course})
 			return
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Course not found"})
+	writeError(w, http.StatusNotFound, "Course not found")
+}
+
+// getPrerequisitesForCourse handles requests for the prerequisites of a
+// course, an extension to OneRoster.
+// @Summary Get prerequisites for a course
+// @Description Retrieves the courses that must be completed before a given course. Courses without prerequisites return an empty collection.
+// @Tags Courses
+// @Produce json
+// @Param id path string true "SourcedId of the course"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. title~'Math' AND schoolYear.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Course
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /courses/{id}/prerequisites [get]
+func (h *APIHandlers) getPrerequisitesForCourse(w http.ResponseWriter, r *http.Request) {
+	courses := visibleOnly(h.Store, h.Store.Courses)
+	course := find(courses, chi.URLParam(r, "id"))
+	if course == nil {
+		writeError(w, http.StatusNotFound, "Course not found")
+		return
+	}
+	prerequisites := []Course{}
+	for _, ref := range course.Prerequisites {
+		if prerequisite := find(courses, ref.SourcedId); prerequisite != nil {
+			prerequisites = append(prerequisites, *prerequisite)
+		}
+	}
+	writeCollection(w, r, courseEnvelope.plural, prerequisites)
 }
 
 // getClasses handles requests for all classes.
 // @Summary Get all classes
-// @Description Retrieves a collection of all scheduled classes.
+// @Description Retrieves a collection of all scheduled classes, optionally only those a given teacher or student is enrolled in and/or those matching a search.
 // @Tags Classes
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. course.sourcedId='<id>' AND classType='scheduled'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param teacherSourcedId query string false "Only return classes the user with this sourcedId teaches"
+// @Param studentSourcedId query string false "Only return classes the user with this sourcedId is enrolled in as a student"
+// @Param search query string false "Only return classes whose title, classCode or a subject contains this text, case-insensitively"
+// @Param include query string false "Set to enrollmentCount to add each class's number of student enrollments as _enrollmentCount"
 // @Success 200 {object} map[string][]Class
+// @Failure 400 {object} map[string]string
 // @Security ApiKeyAuth
 // @Router /classes [get]
 func (h *APIHandlers) getClasses(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]Class{"classes": h.Store.Classes})
+	classes := visibleOnly(h.Store, h.Store.Classes)
+	query := r.URL.Query()
+	for _, by := range []struct{ param, role string }{{"teacherSourcedId", "teacher"}, {"studentSourcedId", "student"}} {
+		if !query.Has(by.param) {
+			continue
+		}
+		userId := query.Get(by.param)
+		enrolled := make(map[string]bool)
+		for _, enrollment := range visibleOnly(h.Store, h.Store.enrollmentsOfUser(userId)) {
+			if enrollment.Role == by.role {
+				enrolled[enrollment.Class.SourcedId] = true
+			}
+		}
+		var matched []Class
+		for _, class := range classes {
+			if enrolled[class.SourcedId] {
+				matched = append(matched, class)
+			}
+		}
+		classes = matched
+	}
+	if search := query.Get("search"); search != "" {
+		classes = slices.DeleteFunc(slices.Clone(classes), func(c Class) bool {
+			return !matchesSearch(search, append([]string{c.Title, c.ClassCode}, c.Subjects...))
+		})
+	}
+	h.writeClasses(w, r, classes)
 }
 
 // getClass handles requests for a single class by SourcedId.
@@ -252,46 +713,1129 @@ func (h *APIHandlers) getClasses(w http.ResponseWriter, r *http.Request) {
 // @Tags Classes
 // @Produce json
 // @Param id path string true "SourcedId of the class"
+// @Param include query string false "Set to enrollmentCount to add the class's number of student enrollments as _enrollmentCount"
 // @Success 200 {object} map[string]Class
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Security ApiKeyAuth
 // @Router /classes/{id} [get]
 func (h *APIHandlers) getClass(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, class := range h.Store.Classes {
-		if class.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]Class{"class": class})
+	for _, class := range visibleOnly(h.Store, h.Store.Classes) {
+		if class.SourcedId != id {
+			continue
+		}
+		if !r.URL.Query().Has("include") {
+			writeJSON(w, http.StatusOK, map[string]Class{classEnvelope.singular: class})
+			return
+		}
+		if !validInclude(w, r) {
 			return
 		}
+		counted := CountedClass{Class: class, EnrollmentCount: h.studentCounts(h.Store.enrollmentsOfClass(id))[id]}
+		writeJSON(w, http.StatusOK, map[string]CountedClass{classEnvelope.singular: counted})
+		return
+	}
+	writeError(w, http.StatusNotFound, "Class not found")
+}
+
+// CountedClass is a class with its student enrollment count, as served with
+// include=enrollmentCount.
+// @Description A class with the number of students enrolled in it.
+type CountedClass struct {
+	Class
+	EnrollmentCount int `json:"_enrollmentCount"`
+}
+
+// writeClasses writes classes as writeCollection does. With
+// include=enrollmentCount each one carries its number of student
+// enrollments, counted as GET /classes/{id}/capacity counts them, so that a
+// class list can show roster sizes without a request per class. The counts
+// are only computed when asked for, before the collection parameters apply,
+// so classes can be sorted by _enrollmentCount.
+func (h *APIHandlers) writeClasses(w http.ResponseWriter, r *http.Request, classes []Class) {
+	if !r.URL.Query().Has("include") {
+		writeCollection(w, r, classEnvelope.plural, classes)
+		return
+	}
+	if !validInclude(w, r) {
+		return
+	}
+	counts := h.studentCounts(h.Store.enrollments())
+	counted := make([]CountedClass, len(classes))
+	for i, class := range classes {
+		counted[i] = CountedClass{Class: class, EnrollmentCount: counts[class.SourcedId]}
+	}
+	writeCollection(w, r, classEnvelope.plural, counted)
+}
+
+// validInclude reports whether the include parameter names enrollmentCount,
+// the only computed field classes offer; otherwise it writes an error.
+func validInclude(w http.ResponseWriter, r *http.Request) bool {
+	if include := r.URL.Query().Get("include"); include != "enrollmentCount" {
+		writeError(w, http.StatusBadRequest, "Invalid include value "+strconv.Quote(include)+": must be enrollmentCount")
+		return false
+	}
+	return true
+}
+
+// studentCounts counts the visible student enrollments among enrollments
+// that aren't to be deleted, by class sourcedId.
+func (h *APIHandlers) studentCounts(enrollments []Enrollment) map[string]int {
+	counts := make(map[string]int)
+	for _, enrollment := range visibleOnly(h.Store, enrollments) {
+		if enrollment.Role == "student" && enrollment.status() != "tobedeleted" {
+			counts[enrollment.Class.SourcedId]++
+		}
+	}
+	return counts
+}
+
+// getClassesForTeacher handles requests for the classes a given teacher
+// teaches.
+// @Summary Get classes for a teacher
+// @Description Retrieves a collection of the classes a given teacher is enrolled in as a teacher, optionally only those in one term.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the teacher"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. course.sourcedId='<id>' AND classType='scheduled'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param termSourcedId query string false "Only return classes in the term with this sourcedId"
+// @Success 200 {object} map[string][]Class
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /teachers/{id}/classes [get]
+func (h *APIHandlers) getClassesForTeacher(w http.ResponseWriter, r *http.Request) {
+	h.writeUserClasses(w, r, "teacher", "Teacher not found")
+}
+
+// getClassesForStudent handles requests for the classes a given student is
+// enrolled in.
+// @Summary Get classes for a student
+// @Description Retrieves a collection of the classes a given student is enrolled in, optionally only those in one term.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the student"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. course.sourcedId='<id>' AND classType='scheduled'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param termSourcedId query string false "Only return classes in the term with this sourcedId"
+// @Success 200 {object} map[string][]Class
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /students/{id}/classes [get]
+func (h *APIHandlers) getClassesForStudent(w http.ResponseWriter, r *http.Request) {
+	h.writeUserClasses(w, r, "student", "Student not found")
+}
+
+// getClassmatesForStudent handles requests for the classmates of a student:
+// every other student enrolled as a student in at least one of their
+// classes, each listed once.
+// @Summary Get classmates for a student
+// @Description Retrieves the distinct students who share at least one class with a given student, the student excluded.
+// @Tags Students
+// @Produce json
+// @Param id path string true "SourcedId of the student"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]User
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /students/{id}/classmates [get]
+func (h *APIHandlers) getClassmatesForStudent(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	users := visibleOnly(h.Store, h.Store.Users)
+	if !slices.ContainsFunc(users, func(u User) bool { return u.SourcedId == id && u.hasRole("student") }) {
+		writeError(w, http.StatusNotFound, "Student not found")
+		return
+	}
+	classmates := make(map[string]bool)
+	for _, enrollment := range visibleOnly(h.Store, h.Store.enrollmentsOfUser(id)) {
+		if enrollment.Role != "student" {
+			continue
+		}
+		for _, classmate := range visibleOnly(h.Store, h.Store.enrollmentsOfClass(enrollment.Class.SourcedId)) {
+			if classmate.Role == "student" && classmate.User.SourcedId != id {
+				classmates[classmate.User.SourcedId] = true
+			}
+		}
+	}
+	var students []User
+	for _, user := range users {
+		if classmates[user.SourcedId] {
+			students = append(students, user)
+		}
+	}
+	writeCollection(w, r, userEnvelope.plural, students)
+}
+
+// writeUserClasses writes the classes the user in the path is enrolled in
+// with the given role. With termSourcedId only classes referencing that term
+// are included; a term id that doesn't name a term is a 400.
+func (h *APIHandlers) writeUserClasses(w http.ResponseWriter, r *http.Request, role, notFound string) {
+	id := chi.URLParam(r, "id")
+	if !slices.ContainsFunc(visibleOnly(h.Store, h.Store.Users), func(u User) bool { return u.SourcedId == id && u.hasRole(role) }) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	termId := r.URL.Query().Get("termSourcedId")
+	if r.URL.Query().Has("termSourcedId") && !slices.ContainsFunc(visibleOnly(h.Store, h.Store.AcademicSessions), func(s AcademicSession) bool {
+		return s.SourcedId == termId && s.Type == "term"
+	}) {
+		writeError(w, http.StatusBadRequest, "Invalid termSourcedId value: no term has sourcedId "+strconv.Quote(termId))
+		return
+	}
+
+	enrolled := make(map[string]bool)
+	for _, enrollment := range visibleOnly(h.Store, h.Store.enrollmentsOfUser(id)) {
+		if enrollment.Role == role {
+			enrolled[enrollment.Class.SourcedId] = true
+		}
+	}
+	var classes []Class
+	for _, class := range visibleOnly(h.Store, h.Store.Classes) {
+		if !enrolled[class.SourcedId] {
+			continue
+		}
+		if termId != "" && !slices.ContainsFunc(class.Terms, func(t GUIDRef) bool { return t.SourcedId == termId }) {
+			continue
+		}
+		classes = append(classes, class)
+	}
+	writeCollection(w, r, classEnvelope.plural, classes)
+}
+
+// getTeachersForClass handles requests for the teachers of a class.
+// @Summary Get teachers for a class
+// @Description Retrieves the users enrolled as teachers in a class. Co-taught classes return more than one teacher.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]User
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/teachers [get]
+func (h *APIHandlers) getTeachersForClass(w http.ResponseWriter, r *http.Request) {
+	h.writeClassUsers(w, r, "teacher", "")
+}
+
+// getStudentsForClass handles requests for the students of a class.
+// @Summary Get students for a class
+// @Description Retrieves the users enrolled as students in a class, optionally only those in a grade.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. familyName='Smith' OR orgs.sourcedId='<id>'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param grade query string false "Only return students in this grade, e.g. 10"
+// @Success 200 {object} map[string][]User
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/students [get]
+func (h *APIHandlers) getStudentsForClass(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Has("grade") && query.Get("grade") == "" {
+		writeError(w, http.StatusBadRequest, "Invalid grade value: must not be empty")
+		return
+	}
+	h.writeClassUsers(w, r, "student", query.Get("grade"))
+}
+
+// writeClassUsers writes the users enrolled with role in the class named by
+// the {id} path parameter, only those in grade unless it is empty.
+func (h *APIHandlers) writeClassUsers(w http.ResponseWriter, r *http.Request, role, grade string) {
+	id := chi.URLParam(r, "id")
+	if !slices.ContainsFunc(visibleOnly(h.Store, h.Store.Classes), func(c Class) bool { return c.SourcedId == id }) {
+		writeError(w, http.StatusNotFound, "Class not found")
+		return
+	}
+	enrolled := make(map[string]bool)
+	for _, enrollment := range visibleOnly(h.Store, h.Store.enrollmentsOfClass(id)) {
+		if enrollment.Role == role {
+			enrolled[enrollment.User.SourcedId] = true
+		}
+	}
+	var users []User
+	for _, user := range visibleOnly(h.Store, h.Store.Users) {
+		if enrolled[user.SourcedId] && (grade == "" || slices.Contains(user.Grades, grade)) {
+			users = append(users, user)
+		}
+	}
+	writeCollection(w, r, userEnvelope.plural, users)
+}
+
+// getAcademicSessionsForClass handles requests for the calendar context of a
+// class: the terms it references, their ancestors such as the school year,
+// and their descendants such as grading periods.
+// @Summary Get academic sessions for a class
+// @Description Retrieves every academic session related to a class: its terms, the sessions above them (e.g. the school year) and the sessions below them (e.g. grading periods), each once.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='term' AND startDate>='2025-01-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]AcademicSession
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/academicSessions [get]
+func (h *APIHandlers) getAcademicSessionsForClass(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	i := slices.IndexFunc(visibleOnly(h.Store, h.Store.Classes), func(c Class) bool { return c.SourcedId == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Class not found")
+		return
+	}
+	class := visibleOnly(h.Store, h.Store.Classes)[i]
+	sessions := make(map[string]AcademicSession)
+	for _, session := range visibleOnly(h.Store, h.Store.AcademicSessions) {
+		sessions[session.SourcedId] = session
+	}
+
+	// Walk up from each term through its parents, then down through the
+	// children. The related set doubles as the visited set, so a cycle in
+	// the hierarchy is walked once.
+	related := make(map[string]bool)
+	var below []GUIDRef
+	for _, term := range class.Terms {
+		for ref := &term; ref != nil && !related[ref.SourcedId]; {
+			session, ok := sessions[ref.SourcedId]
+			if !ok {
+				break
+			}
+			related[ref.SourcedId] = true
+			ref = session.Parent
+		}
+		below = append(below, sessions[term.SourcedId].Children...)
+	}
+	for len(below) > 0 {
+		ref := below[0]
+		below = below[1:]
+		child, ok := sessions[ref.SourcedId]
+		if !ok || related[ref.SourcedId] {
+			continue
+		}
+		related[ref.SourcedId] = true
+		below = append(below, child.Children...)
+	}
+
+	var matched []AcademicSession
+	for _, session := range visibleOnly(h.Store, h.Store.AcademicSessions) {
+		if related[session.SourcedId] {
+			matched = append(matched, session)
+		}
+	}
+	writeCollection(w, r, academicSessionEnvelope.plural, matched)
+}
+
+// ClassCapacity compares a class's enrollment cap with its enrollment.
+// @Description The enrollment cap of a class against its current student enrollment.
+type ClassCapacity struct {
+	MaxEnrollment   *int `json:"maxEnrollment"` // null when the class has no cap
+	EnrollmentCount int  `json:"enrollmentCount"`
+	OverCapacity    bool `json:"overCapacity"`
+}
+
+// getClassCapacity handles requests for the capacity of a class.
+// @Summary Get the capacity of a class
+// @Description Compares the class's maxEnrollment metadata with the number of students enrolled in it. Classes without a cap report a null maxEnrollment and are never over capacity.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Success 200 {object} ClassCapacity
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/capacity [get]
+func (h *APIHandlers) getClassCapacity(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	classes := visibleOnly(h.Store, h.Store.Classes)
+	i := slices.IndexFunc(classes, func(c Class) bool { return c.SourcedId == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Class not found")
+		return
+	}
+	capacity := ClassCapacity{EnrollmentCount: h.studentCounts(h.Store.enrollmentsOfClass(id))[id]}
+	if seats := maxEnrollment(classes[i]); seats > 0 {
+		capacity.MaxEnrollment = &seats
+		capacity.OverCapacity = capacity.EnrollmentCount > seats
+	}
+	writeJSON(w, http.StatusOK, capacity)
+}
+
+// maxEnrollment returns the enrollment cap in a class's metadata, or 0 when
+// it has none. Metadata is a *ClassMetadata for generated classes and a
+// decoded JSON object for classes loaded from a snapshot.
+func maxEnrollment(class Class) int {
+	switch metadata := class.Metadata.(type) {
+	case *ClassMetadata:
+		return metadata.MaxEnrollment
+	case map[string]any:
+		if seats, ok := metadata["maxEnrollment"].(float64); ok {
+			return int(seats)
+		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Class not found"})
+	return 0
+}
+
+// getClassMetadata handles requests for just the metadata block of a class.
+// @Summary Get a class's metadata
+// @Description Retrieves only the metadata extension block of a class. Classes without metadata return an empty object.
+// @Tags Classes
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Success 200 {object} ClassMetadata
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/metadata [get]
+func (h *APIHandlers) getClassMetadata(w http.ResponseWriter, r *http.Request) {
+	writeMetadata(w, r, visibleOnly(h.Store, h.Store.Classes), "Class not found")
+}
+
+// getCategories handles requests for all grading categories.
+// @Summary Get all categories
+// @Description Retrieves a collection of all grading categories, optionally only those weighing at least minWeight.
+// @Tags Categories
+// @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. weight>='20'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param minWeight query int false "Only return categories whose weight is at least this"
+// @Success 200 {object} map[string][]Category
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /categories [get]
+func (h *APIHandlers) getCategories(w http.ResponseWriter, r *http.Request) {
+	writeCategories(w, r, visibleOnly(h.Store, h.Store.Categories))
 }
 
 // getCategoriesForClass handles requests for categories for a given class.
 // @Summary Get categories for a class
-// @Description Retrieves a collection of grading categories for a given class.
+// @Description Retrieves a collection of grading categories for a given class, optionally only those weighing at least minWeight.
 // @Tags Classes
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. weight>='20'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Param id path string true "SourcedId of the class"
+// @Param minWeight query int false "Only return categories whose weight is at least this"
 // @Success 200 {object} map[string][]Category
+// @Failure 400 {object} map[string]string
 // @Security ApiKeyAuth
 // @Router /classes/{id}/categories [get]
 func (h *APIHandlers) getCategoriesForClass(w http.ResponseWriter, r *http.Request) {
 	// In this mock, categories are global, not class-specific.
 	// A real implementation would filter based on the class ID.
-	writeJSON(w, http.StatusOK, map[string][]Category{"categories": h.Store.Categories})
+	writeCategories(w, r, visibleOnly(h.Store, h.Store.Categories))
+}
+
+// writeCategories applies the minWeight parameter to categories and writes
+// the collection.
+func writeCategories(w http.ResponseWriter, r *http.Request, categories []Category) {
+	if r.URL.Query().Has("minWeight") {
+		minWeight, err := strconv.Atoi(r.URL.Query().Get("minWeight"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid minWeight value: must be an integer")
+			return
+		}
+		categories = slices.DeleteFunc(slices.Clone(categories), func(c Category) bool { return c.Weight < minWeight })
+	}
+	writeCollection(w, r, categoryEnvelope.plural, categories)
+}
+
+// getResources handles requests for all resources.
+// The role and importance filters are combined with AND.
+// @Summary Get all resources
+// @Description Retrieves a collection of all learning resources, optionally only those meant for a role and/or of an importance.
+// @Tags Resources
+// @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. importance='primary'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param role query string false "Only return resources whose roles include this one"
+// @Param importance query string false "Only return resources of this importance: primary or secondary"
+// @Success 200 {object} map[string][]Resource
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /resources [get]
+func (h *APIHandlers) getResources(w http.ResponseWriter, r *http.Request) {
+	writeResources(w, r, visibleOnly(h.Store, h.Store.Resources))
+}
+
+// getResource handles requests for a single resource by SourcedId.
+// @Summary Get a specific resource
+// @Description Retrieves a single learning resource by its sourcedId.
+// @Tags Resources
+// @Produce json
+// @Param id path string true "SourcedId of the resource"
+// @Success 200 {object} map[string]Resource
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /resources/{id} [get]
+func (h *APIHandlers) getResource(w http.ResponseWriter, r *http.Request) {
+	resource := find(visibleOnly(h.Store, h.Store.Resources), chi.URLParam(r, "id"))
+	if resource == nil {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]Resource{resourceEnvelope.singular: *resource})
+}
+
+// getResourcesForClass handles requests for the resources of a class: the
+// room generated classes meet in, unless they are online.
+// @Summary Get resources for a class
+// @Description Retrieves the learning resources a class links to, optionally only those meant for a role and/or of an importance.
+// @Tags Resources
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. importance='primary'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param role query string false "Only return resources whose roles include this one"
+// @Param importance query string false "Only return resources of this importance: primary or secondary"
+// @Success 200 {object} map[string][]Resource
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/resources [get]
+func (h *APIHandlers) getResourcesForClass(w http.ResponseWriter, r *http.Request) {
+	class := find(visibleOnly(h.Store, h.Store.Classes), chi.URLParam(r, "id"))
+	if class == nil {
+		writeError(w, http.StatusNotFound, "Class not found")
+		return
+	}
+	all := visibleOnly(h.Store, h.Store.Resources)
+	resources := []Resource{}
+	for _, ref := range class.Resources {
+		if resource := find(all, ref.SourcedId); resource != nil {
+			resources = append(resources, *resource)
+		}
+	}
+	writeResources(w, r, resources)
+}
+
+// writeResources applies the role and importance parameters to resources and
+// writes the collection.
+func writeResources(w http.ResponseWriter, r *http.Request, resources []Resource) {
+	query := r.URL.Query()
+	if query.Has("role") {
+		role := query.Get("role")
+		if !slices.Contains(userRoles, role) {
+			writeError(w, http.StatusBadRequest, "Invalid role "+strconv.Quote(role)+": must be one of "+strings.Join(userRoles, ", "))
+			return
+		}
+		resources = slices.DeleteFunc(slices.Clone(resources), func(res Resource) bool { return !slices.Contains(res.Roles, role) })
+	}
+	if query.Has("importance") {
+		importance := query.Get("importance")
+		if !slices.Contains(resourceImportances, importance) {
+			writeError(w, http.StatusBadRequest, "Invalid importance "+strconv.Quote(importance)+": must be one of "+strings.Join(resourceImportances, ", "))
+			return
+		}
+		resources = slices.DeleteFunc(slices.Clone(resources), func(res Resource) bool { return res.Importance != importance })
+	}
+	writeCollection(w, r, resourceEnvelope.plural, resources)
+}
+
+// getLineItems handles requests for all line items.
+// The class and grading period filters are combined with AND.
+// @Summary Get all line items
+// @Description Retrieves a collection of all line items, optionally scoped to a class and/or grading period.
+// @Tags Line Items
+// @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. class.sourcedId='<id>' AND dueDate<'2025-06-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param classSourcedId query string false "Only return line items for the class with this sourcedId"
+// @Param gradingPeriodSourcedId query string false "Only return line items in the grading period with this sourcedId"
+// @Success 200 {object} map[string][]LineItem
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /lineItems [get]
+func (h *APIHandlers) getLineItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	for _, param := range []string{"classSourcedId", "gradingPeriodSourcedId"} {
+		if query.Has(param) && query.Get(param) == "" {
+			writeError(w, http.StatusBadRequest, "Invalid "+param+" value: must not be empty")
+			return
+		}
+	}
+	classId := query.Get("classSourcedId")
+	periodId := query.Get("gradingPeriodSourcedId")
+
+	var lineItems []LineItem
+	for _, lineItem := range visibleOnly(h.Store, h.Store.LineItems) {
+		if classId != "" && lineItem.Class.SourcedId != classId {
+			continue
+		}
+		if periodId != "" && lineItem.GradingPeriod.SourcedId != periodId {
+			continue
+		}
+		lineItems = append(lineItems, lineItem)
+	}
+	writeCollection(w, r, lineItemEnvelope.plural, lineItems)
+}
+
+// getLineItem handles requests for a single line item by SourcedId.
+// @Summary Get a specific line item
+// @Description Retrieves a single line item by its sourcedId.
+// @Tags Line Items
+// @Produce json
+// @Param id path string true "SourcedId of the line item"
+// @Success 200 {object} map[string]LineItem
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /lineItems/{id} [get]
+func (h *APIHandlers) getLineItem(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	for _, lineItem := range visibleOnly(h.Store, h.Store.LineItems) {
+		if lineItem.SourcedId == id {
+			writeJSON(w, http.StatusOK, map[string]LineItem{lineItemEnvelope.singular: lineItem})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Line Item not found")
+}
+
+// derefResults copies indexed results into a slice of values.
+func derefResults(results []*Result) []Result {
+	values := make([]Result, 0, len(results))
+	for _, result := range results {
+		values = append(values, *result)
+	}
+	return values
+}
+
+// sortByScoreDate orders results by scoreDate, the default order of every
+// results collection.
+func sortByScoreDate(results []Result) {
+	slices.SortStableFunc(results, func(a, b Result) int { return strings.Compare(a.ScoreDate, b.ScoreDate) })
+}
+
+// getResults handles requests for all results, ordered by scoreDate unless
+// another sort is requested.
+// @Summary Get all results
+// @Description Retrieves a collection of all results, ordered by scoreDate by default, optionally only those with a given score status. Use limit and offset to page through them.
+// @Tags Results
+// @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score>='90'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param scoreStatus query string false "Only return results with this score status (exempt, fully graded, not submitted, partially graded or submitted)"
+// @Param expand query string false "Set to lineItem to embed the line item of each result under lineItemObject"
+// @Success 200 {object} map[string][]Result
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /results [get]
+func (h *APIHandlers) getResults(w http.ResponseWriter, r *http.Request) {
+	results := slices.Clone(visibleOnly(h.Store, h.Store.Results))
+	if query := r.URL.Query(); query.Has("scoreStatus") {
+		scoreStatus := query.Get("scoreStatus")
+		if !slices.Contains(scoreStatuses, scoreStatus) {
+			writeError(w, http.StatusBadRequest, "Invalid scoreStatus value: must be one of "+strings.Join(scoreStatuses, ", "))
+			return
+		}
+		results = slices.DeleteFunc(results, func(result Result) bool { return result.ScoreStatus != scoreStatus })
+	}
+	sortByScoreDate(results)
+	h.writeResults(w, r, results)
+}
+
+// ExpandedResult is a result with its line item embedded, as served with
+// expand=lineItem.
+// @Description A result with its line item resolved; lineItemObject is null when the line item doesn't exist.
+type ExpandedResult struct {
+	Result
+	LineItemObject *LineItem `json:"lineItemObject"`
+}
+
+// writeResults writes results as writeCollection does. With expand=lineItem
+// each one carries its line item under lineItemObject, for gradebook views
+// that would otherwise look every line item up. A line item that doesn't
+// resolve is embedded as null, and a Warning header names it; the
+// warnings cover results before the collection parameters apply, so they
+// may name line items of results left off the page.
+func (h *APIHandlers) writeResults(w http.ResponseWriter, r *http.Request, results []Result) {
+	query := r.URL.Query()
+	if !query.Has("expand") {
+		writeCollection(w, r, resultEnvelope.plural, results)
+		return
+	}
+	if expand := query.Get("expand"); expand != "lineItem" {
+		writeError(w, http.StatusBadRequest, "Invalid expand value "+strconv.Quote(expand)+": must be lineItem")
+		return
+	}
+	lineItems := make(map[string]*LineItem)
+	for _, lineItem := range visibleOnly(h.Store, h.Store.LineItems) {
+		lineItems[lineItem.SourcedId] = &lineItem
+	}
+	expanded := make([]ExpandedResult, len(results))
+	missing := make(map[string]bool)
+	for i, result := range results {
+		expanded[i] = ExpandedResult{Result: result, LineItemObject: lineItems[result.LineItem.SourcedId]}
+		if id := result.LineItem.SourcedId; expanded[i].LineItemObject == nil && !missing[id] {
+			missing[id] = true
+			w.Header().Add("Warning", `199 - "lineItem `+id+` not found; lineItemObject is null"`)
+		}
+	}
+	writeCollection(w, r, resultEnvelope.plural, expanded)
+}
+
+// getResult handles requests for a single result by SourcedId.
+// @Summary Get a specific result
+// @Description Retrieves a single result by its sourcedId.
+// @Tags Results
+// @Produce json
+// @Param id path string true "SourcedId of the result"
+// @Success 200 {object} map[string]Result
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /results/{id} [get]
+func (h *APIHandlers) getResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	for _, result := range visibleOnly(h.Store, h.Store.Results) {
+		if result.SourcedId == id {
+			writeJSON(w, http.StatusOK, map[string]Result{resultEnvelope.singular: result})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Result not found")
+}
+
+// getResultsForStudent handles requests for the results of a given student,
+// served from the store's per-student index.
+// @Summary Get results for a student
+// @Description Retrieves a collection of results for a given student, ordered by scoreDate by default.
+// @Tags Results
+// @Produce json
+// @Param id path string true "SourcedId of the student"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score>='90'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param expand query string false "Set to lineItem to embed the line item of each result under lineItemObject"
+// @Success 200 {object} map[string][]Result
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /students/{id}/results [get]
+func (h *APIHandlers) getResultsForStudent(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	for _, user := range visibleOnly(h.Store, h.Store.Users) {
+		if user.SourcedId == id && user.hasRole("student") {
+			results := derefResults(h.Store.resultsByStudent[id])
+			sortByScoreDate(results)
+			h.writeResults(w, r, visibleOnly(h.Store, results))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Student not found")
+}
+
+// getResultsForClass handles requests for the results in a given class,
+// served from the store's per-class index.
+// @Summary Get results for a class
+// @Description Retrieves a collection of results for all line items of a given class, ordered by scoreDate by default.
+// @Tags Results
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score>='90'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Result
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/results [get]
+func (h *APIHandlers) getResultsForClass(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	for _, class := range visibleOnly(h.Store, h.Store.Classes) {
+		if class.SourcedId == id {
+			results := derefResults(h.Store.resultsByClass[id])
+			sortByScoreDate(results)
+			writeCollection(w, r, resultEnvelope.plural, visibleOnly(h.Store, results))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Class not found")
+}
+
+// getResultsForStudentForClass handles requests for the results of a given
+// student in a given class, served from the store's per-class index.
+// @Summary Get results for a student in a class
+// @Description Retrieves a collection of the results of a student for the line items of a class the student is enrolled in, ordered by scoreDate by default.
+// @Tags Results
+// @Produce json
+// @Param id path string true "SourcedId of the class"
+// @Param studentId path string true "SourcedId of the student"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. scoreStatus='fully graded' AND score>='90'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Result
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/{id}/students/{studentId}/results [get]
+func (h *APIHandlers) getResultsForStudentForClass(w http.ResponseWriter, r *http.Request) {
+	ds := h.Store
+	classId, studentId := chi.URLParam(r, "id"), chi.URLParam(r, "studentId")
+	if !slices.ContainsFunc(visibleOnly(ds, ds.Classes), func(c Class) bool { return c.SourcedId == classId }) {
+		writeError(w, http.StatusNotFound, "Class not found")
+		return
+	}
+	if !slices.ContainsFunc(visibleOnly(ds, ds.Users), func(u User) bool { return u.SourcedId == studentId && u.hasRole("student") }) {
+		writeError(w, http.StatusNotFound, "Student not found")
+		return
+	}
+	if !slices.ContainsFunc(visibleOnly(ds, ds.enrollmentsOfClass(classId)), func(e Enrollment) bool {
+		return e.User.SourcedId == studentId && e.Role == "student"
+	}) {
+		writeError(w, http.StatusNotFound, "Student is not enrolled in this class")
+		return
+	}
+	var results []Result
+	for _, result := range derefResults(ds.resultsByClass[classId]) {
+		if result.Student.SourcedId == studentId {
+			results = append(results, result)
+		}
+	}
+	sortByScoreDate(results)
+	writeCollection(w, r, resultEnvelope.plural, visibleOnly(ds, results))
+}
+
+// Transcript is a student's grade history in one object: each enrollment
+// with its class, course and the student's results in the class. A reference
+// that doesn't resolve is null.
+// @Description A student with every enrollment, its class and course, and the student's results in the class.
+type Transcript struct {
+	Student User              `json:"student"`
+	Classes []TranscriptClass `json:"classes"`
+}
+
+// TranscriptClass is one enrollment of a transcript.
+// @Description An enrollment of a transcript with its class, course and the student's results.
+type TranscriptClass struct {
+	Enrollment Enrollment         `json:"enrollment"`
+	Class      *Class             `json:"class"`
+	Course     *Course            `json:"course"`
+	Results    []TranscriptResult `json:"results"`
+}
+
+// TranscriptResult is a result of a transcript with its line item.
+// @Description A result with its line item resolved.
+type TranscriptResult struct {
+	Result   Result    `json:"result"`
+	LineItem *LineItem `json:"lineItem"`
+}
+
+// getTranscript handles requests for a student's transcript, a denormalized
+// view for transcript renderers. Enrollments are ordered by beginDate and
+// results by scoreDate, as in their own collections.
+// @Summary Get a student's transcript
+// @Description Retrieves a student with every enrollment, each with its class, the class's course and the student's results in the class with their line items, all resolved in one object.
+// @Tags Students
+// @Produce json
+// @Param id path string true "SourcedId of the student"
+// @Success 200 {object} Transcript
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /students/{id}/transcript [get]
+func (h *APIHandlers) getTranscript(w http.ResponseWriter, r *http.Request) {
+	ds := h.Store
+	id := chi.URLParam(r, "id")
+	student := find(visibleOnly(ds, ds.Users), id)
+	if student == nil || !student.hasRole("student") {
+		writeError(w, http.StatusNotFound, "Student not found")
+		return
+	}
+	lineItems := visibleOnly(ds, ds.LineItems)
+	lineItemsById := make(map[string]*LineItem, len(lineItems))
+	for i := range lineItems {
+		lineItemsById[lineItems[i].SourcedId] = &lineItems[i]
+	}
+	results := visibleOnly(ds, derefResults(ds.resultsByStudent[id]))
+	sortByScoreDate(results)
+	resultsByClass := make(map[string][]TranscriptResult)
+	for _, result := range results {
+		lineItem := lineItemsById[result.LineItem.SourcedId]
+		if lineItem == nil {
+			continue
+		}
+		resultsByClass[lineItem.Class.SourcedId] = append(resultsByClass[lineItem.Class.SourcedId], TranscriptResult{Result: result, LineItem: lineItem})
+	}
+
+	transcript := Transcript{Student: *student, Classes: []TranscriptClass{}}
+	for _, enrollment := range visibleOnly(ds, ds.enrollmentsOfUser(id)) {
+		entry := TranscriptClass{Enrollment: enrollment, Results: []TranscriptResult{}}
+		if entry.Class = find(visibleOnly(ds, ds.Classes), enrollment.Class.SourcedId); entry.Class != nil {
+			entry.Course = find(visibleOnly(ds, ds.Courses), entry.Class.Course.SourcedId)
+		}
+		if classResults, ok := resultsByClass[enrollment.Class.SourcedId]; ok {
+			entry.Results = classResults
+		}
+		transcript.Classes = append(transcript.Classes, entry)
+	}
+	slices.SortStableFunc(transcript.Classes, func(a, b TranscriptClass) int {
+		return strings.Compare(a.Enrollment.BeginDate, b.Enrollment.BeginDate)
+	})
+	writeJSON(w, http.StatusOK, transcript)
 }
 
 // getEnrollments handles requests for all enrollments.
+// The optional query parameters are combined with AND, so
+// ?primary=true&role=teacher returns the primary teacher of every class.
 // @Summary Get all enrollments
-// @Description Retrieves a collection of all user enrollments in classes.
+// @Description Retrieves a collection of all user enrollments in classes, optionally filtered by role, class and primary flag.
 // @Tags Enrollments
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. role='teacher' AND primary='true'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Param role query string false "Only return enrollments with this role"
+// @Param classSourcedId query string false "Only return enrollments in the class with this sourcedId"
+// @Param primary query bool false "Only return enrollments whose primary flag matches"
+// @Param lifecycleState query string false "Only return enrollments in this state on the server's current date: upcoming (beginDate after it), active or ended (endDate before it)"
 // @Success 200 {object} map[string][]Enrollment
+// @Failure 400 {object} map[string]string
 // @Security ApiKeyAuth
 // @Router /enrollments [get]
 func (h *APIHandlers) getEnrollments(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]Enrollment{"enrollments": h.Store.Enrollments})
+	query := r.URL.Query()
+	role := query.Get("role")
+	classId := query.Get("classSourcedId")
+	var primary *bool
+	if query.Has("primary") {
+		value, err := strconv.ParseBool(query.Get("primary"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid primary value: must be true or false")
+			return
+		}
+		primary = &value
+	}
+	state := query.Get("lifecycleState")
+	if query.Has("lifecycleState") && !slices.Contains(lifecycleStates, state) {
+		writeError(w, http.StatusBadRequest, "Invalid lifecycleState value "+strconv.Quote(state)+": must be one of "+strings.Join(lifecycleStates, ", "))
+		return
+	}
+	today := h.Store.now().Format(time.DateOnly)
+
+	// Filtering by class needs only that class's enrollments built.
+	var candidates []Enrollment
+	if classId != "" {
+		candidates = h.Store.enrollmentsOfClass(classId)
+	} else {
+		candidates = h.Store.enrollments()
+	}
+	var enrollments []Enrollment
+	for _, enrollment := range visibleOnly(h.Store, candidates) {
+		if role != "" && enrollment.Role != role {
+			continue
+		}
+		if primary != nil && enrollment.Primary != *primary {
+			continue
+		}
+		if state != "" && enrollment.lifecycleState(today) != state {
+			continue
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	writeCollection(w, r, enrollmentEnvelope.plural, enrollments)
+}
+
+// lifecycleStates are the values of the lifecycleState enrollment filter.
+var lifecycleStates = []string{"upcoming", "active", "ended"}
+
+// lifecycleState returns whether the enrollment is upcoming, active or ended
+// on today, a date in time.DateOnly form. Both dates are inclusive; a missing
+// date leaves that end of the enrollment open.
+func (e Enrollment) lifecycleState(today string) string {
+	switch {
+	case e.BeginDate != "" && e.BeginDate > today:
+		return "upcoming"
+	case e.EndDate != "" && e.EndDate < today:
+		return "ended"
+	}
+	return "active"
 }
 
 // getEnrollment handles requests for a single enrollment by SourcedId.
@@ -306,13 +1850,109 @@ func (h *APIHandlers) getEnrollments(w http.ResponseWriter, r *http.Request) {
 // @Router /enrollments/{id} [get]
 func (h *APIHandlers) getEnrollment(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, enrollment := range h.Store.Enrollments {
+	for _, enrollment := range visibleOnly(h.Store, h.Store.enrollments()) {
 		if enrollment.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]Enrollment{"enrollment": enrollment})
+			w.Header().Set("ETag", entityTag(enrollment))
+			writeJSON(w, http.StatusOK, map[string]Enrollment{enrollmentEnvelope.singular: enrollment})
 			return
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Enrollment not found"})
+	writeError(w, http.StatusNotFound, "Enrollment not found")
+}
+
+// EnrollmentRelated is an enrollment together with the records it refers
+// to, directly or through its class. A reference that doesn't resolve is
+// null.
+// @Description An enrollment with its user, class, course, school and terms dereferenced.
+type EnrollmentRelated struct {
+	Enrollment Enrollment        `json:"enrollment"`
+	User       *User             `json:"user"`
+	Class      *Class            `json:"class"`
+	Course     *Course           `json:"course"`
+	School     *Org              `json:"school"`
+	Terms      []AcademicSession `json:"terms"`
+}
+
+// getEnrollmentRelated handles requests for an enrollment and the records
+// it relates to, a convenience view for support tooling.
+// @Summary Get an enrollment with its related records
+// @Description Retrieves an enrollment with its user, class, the class's course, the school and the class's terms resolved in one object.
+// @Tags Enrollments
+// @Produce json
+// @Param id path string true "SourcedId of the enrollment"
+// @Success 200 {object} EnrollmentRelated
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /enrollments/{id}/related [get]
+func (h *APIHandlers) getEnrollmentRelated(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	ds := h.Store
+	enrollments := visibleOnly(ds, ds.enrollments())
+	i := slices.IndexFunc(enrollments, func(e Enrollment) bool { return e.SourcedId == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Enrollment not found")
+		return
+	}
+	related := EnrollmentRelated{Enrollment: enrollments[i], Terms: []AcademicSession{}}
+	related.User = find(visibleOnly(ds, ds.Users), related.Enrollment.User.SourcedId)
+	related.School = find(visibleOnly(ds, ds.Orgs), related.Enrollment.School.SourcedId)
+	if related.Class = find(visibleOnly(ds, ds.Classes), related.Enrollment.Class.SourcedId); related.Class != nil {
+		related.Course = find(visibleOnly(ds, ds.Courses), related.Class.Course.SourcedId)
+		for _, ref := range related.Class.Terms {
+			if term := find(visibleOnly(ds, ds.AcademicSessions), ref.SourcedId); term != nil {
+				related.Terms = append(related.Terms, *term)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, related)
+}
+
+// find returns a copy of the item with the given SourcedId, or nil.
+func find[T entity](items []T, id string) *T {
+	for _, item := range items {
+		if item.sourcedID() == id {
+			return &item
+		}
+	}
+	return nil
+}
+
+// getEnrollmentsForUser handles requests for the enrollments of a given user,
+// across every term, ordered by beginDate by default so that a student's
+// history reads oldest first.
+// @Summary Get enrollments for a user
+// @Description Retrieves a collection of all enrollments of a given user across all terms, ordered by beginDate by default.
+// @Tags Enrollments
+// @Produce json
+// @Param id path string true "SourcedId of the user"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. role='teacher' AND primary='true'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Enrollment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /users/{id}/enrollments [get]
+func (h *APIHandlers) getEnrollmentsForUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !slices.ContainsFunc(visibleOnly(h.Store, h.Store.Users), func(u User) bool { return u.SourcedId == id }) {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	enrollments := visibleOnly(h.Store, h.Store.enrollmentsOfUser(id))
+	slices.SortStableFunc(enrollments, func(a, b Enrollment) int { return strings.Compare(a.BeginDate, b.BeginDate) })
+	writeCollection(w, r, enrollmentEnvelope.plural, enrollments)
 }
 
 // getTerms handles requests for academic sessions of type 'term'.
@@ -320,17 +1960,25 @@ func (h *APIHandlers) getEnrollment(w http.ResponseWriter, r *http.Request) {
 // @Description Retrieves a collection of all academic sessions with type 'term'.
 // @Tags Academic Sessions
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='term' AND startDate>='2025-01-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Success 200 {object} map[string][]AcademicSession
 // @Security ApiKeyAuth
 // @Router /terms [get]
 func (h *APIHandlers) getTerms(w http.ResponseWriter, r *http.Request) {
-	var terms []AcademicSession
-	for _, session := range h.Store.AcademicSessions {
-		if session.Type == "term" {
-			terms = append(terms, session)
-		}
-	}
-	writeJSON(w, http.StatusOK, map[string][]AcademicSession{"academicSessions": terms})
+	h.sessionView("term", "Term not found").list(w, r)
 }
 
 // getTerm handles requests for a single term by SourcedId.
@@ -344,14 +1992,7 @@ func (h *APIHandlers) getTerms(w http.ResponseWriter, r *http.Request) {
 // @Security ApiKeyAuth
 // @Router /terms/{id} [get]
 func (h *APIHandlers) getTerm(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	for _, session := range h.Store.AcademicSessions {
-		if session.SourcedId == id && session.Type == "term" {
-			writeJSON(w, http.StatusOK, map[string]AcademicSession{"academicSession": session})
-			return
-		}
-	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Term not found"})
+	h.sessionView("term", "Term not found").get(w, r)
 }
 
 // getAcademicSessions handles requests for all academic sessions.
@@ -359,11 +2000,25 @@ func (h *APIHandlers) getTerm(w http.ResponseWriter, r *http.Request) {
 // @Description Retrieves a collection of all academic sessions of any type.
 // @Tags Academic Sessions
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='term' AND startDate>='2025-01-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Success 200 {object} map[string][]AcademicSession
 // @Security ApiKeyAuth
 // @Router /academicSessions [get]
 func (h *APIHandlers) getAcademicSessions(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string][]AcademicSession{"academicSessions": h.Store.AcademicSessions})
+	writeCollection(w, r, academicSessionEnvelope.plural, visibleOnly(h.Store, h.Store.AcademicSessions))
 }
 
 // getAcademicSession handles requests for a single academic session by SourcedId.
@@ -378,13 +2033,108 @@ func (h *APIHandlers) getAcademicSessions(w http.ResponseWriter, r *http.Request
 // @Router /academicSessions/{id} [get]
 func (h *APIHandlers) getAcademicSession(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, session := range h.Store.AcademicSessions {
+	for _, session := range visibleOnly(h.Store, h.Store.AcademicSessions) {
 		if session.SourcedId == id {
-			writeJSON(w, http.StatusOK, map[string]AcademicSession{"academicSession": session})
+			writeJSON(w, http.StatusOK, map[string]AcademicSession{academicSessionEnvelope.singular: session})
 			return
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Academic Session not found"})
+	writeError(w, http.StatusNotFound, "Academic Session not found")
+}
+
+// getClassesForAcademicSession handles requests for the classes of an
+// academic session and the sessions below it.
+// @Summary Get classes for an academic session
+// @Description Retrieves the classes whose terms reference the academic session or any session below it, so a school year returns the classes of all its terms.
+// @Tags Academic Sessions
+// @Produce json
+// @Param id path string true "SourcedId of the academic session"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. course.sourcedId='<id>' AND classType='scheduled'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Class
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /academicSessions/{id}/classes [get]
+func (h *APIHandlers) getClassesForAcademicSession(w http.ResponseWriter, r *http.Request) {
+	h.writeClassesForSession(w, r, "", "Academic Session not found")
+}
+
+// getClassesForTerm handles requests for the classes of a term.
+// @Summary Get classes for a term
+// @Description Retrieves the classes whose terms reference the term or one of its grading periods.
+// @Tags Terms
+// @Produce json
+// @Param id path string true "SourcedId of the term"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. course.sourcedId='<id>' AND classType='scheduled'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]Class
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /terms/{id}/classes [get]
+func (h *APIHandlers) getClassesForTerm(w http.ResponseWriter, r *http.Request) {
+	h.writeClassesForSession(w, r, "term", "Term not found")
+}
+
+// writeClassesForSession writes the classes referencing the session named by
+// the {id} path parameter or any of its descendants. A non-empty
+// sessionType restricts the route to sessions of that type.
+func (h *APIHandlers) writeClassesForSession(w http.ResponseWriter, r *http.Request, sessionType, notFound string) {
+	id := chi.URLParam(r, "id")
+	sessions := make(map[string]AcademicSession)
+	for _, session := range visibleOnly(h.Store, h.Store.AcademicSessions) {
+		sessions[session.SourcedId] = session
+	}
+	root, ok := sessions[id]
+	if !ok || (sessionType != "" && root.Type != sessionType) {
+		writeError(w, http.StatusNotFound, notFound)
+		return
+	}
+	// Collect the session and everything below it, visiting each session
+	// once in case the hierarchy has a cycle.
+	inScope := map[string]bool{id: true}
+	queue := root.Children
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		child, ok := sessions[ref.SourcedId]
+		if !ok || inScope[ref.SourcedId] {
+			continue
+		}
+		inScope[ref.SourcedId] = true
+		queue = append(queue, child.Children...)
+	}
+
+	var classes []Class
+	for _, class := range visibleOnly(h.Store, h.Store.Classes) {
+		if slices.ContainsFunc(class.Terms, func(t GUIDRef) bool { return inScope[t.SourcedId] }) {
+			classes = append(classes, class)
+		}
+	}
+	writeCollection(w, r, classEnvelope.plural, classes)
 }
 
 // getGradingPeriods handles requests for academic sessions of type 'gradingPeriod'.
@@ -392,17 +2142,25 @@ func (h *APIHandlers) getAcademicSession(w http.ResponseWriter, r *http.Request)
 // @Description Retrieves a collection of all academic sessions with type 'gradingPeriod'.
 // @Tags Academic Sessions
 // @Produce json
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. type='term' AND startDate>='2025-01-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
 // @Success 200 {object} map[string][]AcademicSession
 // @Security ApiKeyAuth
 // @Router /gradingPeriods [get]
-func (h *APIHandlers) getGradingPeriods(w http.ResponseWriter, _ *http.Request) {
-	var periods []AcademicSession
-	for _, session := range h.Store.AcademicSessions {
-		if session.Type == "gradingPeriod" {
-			periods = append(periods, session)
-		}
-	}
-	writeJSON(w, http.StatusOK, map[string][]AcademicSession{"academicSessions": periods})
+func (h *APIHandlers) getGradingPeriods(w http.ResponseWriter, r *http.Request) {
+	h.sessionView("gradingPeriod", "Grading Period not found").list(w, r)
 }
 
 // getGradingPeriod handles requests for a single grading period by SourcedId.
@@ -416,12 +2174,47 @@ func (h *APIHandlers) getGradingPeriods(w http.ResponseWriter, _ *http.Request)
 // @Security ApiKeyAuth
 // @Router /gradingPeriods/{id} [get]
 func (h *APIHandlers) getGradingPeriod(w http.ResponseWriter, r *http.Request) {
+	h.sessionView("gradingPeriod", "Grading Period not found").get(w, r)
+}
+
+// getLineItemsForGradingPeriod handles requests for the line items of a
+// grading period.
+// @Summary Get line items for a grading period
+// @Description Retrieves the line items whose gradingPeriod references the grading period.
+// @Tags Line Items
+// @Produce json
+// @Param id path string true "SourcedId of the grading period"
+// @Param status query string false "Only return items with this status (active, tobedeleted or inactive; case-insensitive)"
+// @Param showDeleted query string false "Which tobedeleted items to return: true includes them, false leaves them out, only returns just them" Enums(true, false, only) default(true)
+// @Param modifiedSince query string false "Only items whose dateLastModified is at or after this RFC 3339 timestamp or date"
+// @Param filter query string false "OneRoster filter expression, e.g. class.sourcedId='<id>' AND dueDate<'2025-06-01'"
+// @Param sort query string false "Field to sort by"
+// @Param orderBy query string false "Sort direction: asc or desc"
+// @Param limit query int false "Maximum number of items to return; 0 returns an empty page with only the X-Total-Count header"
+// @Param offset query int false "Number of items to skip"
+// @Param after query string false "Cursor paging: return the items after this cursor, ordered by sourcedId; start with an empty value and follow the next Link. Cannot be combined with offset or sort"
+// @Param envelope query bool false "Set to false to return a bare JSON array instead of the OneRoster envelope object"
+// @Param idsOnly query bool false "Set to true to return only the sourcedIds of the page, as {\"sourcedIds\": [...]}"
+// @Param consistentSnapshot query bool false "Set to true on the first page to page through a frozen view of the collection; its token comes back in the X-Snapshot-Token header"
+// @Param snapshotToken query string false "Read this page from the frozen view with this token, ignoring filter and sort"
+// @Param fields query string false "Comma-separated fields to return of each item, e.g. sourcedId,familyName"
+// @Success 200 {object} map[string][]LineItem
+// @Failure 404 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /gradingPeriods/{id}/lineItems [get]
+func (h *APIHandlers) getLineItemsForGradingPeriod(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	for _, session := range h.Store.AcademicSessions {
-		if session.SourcedId == id && session.Type == "gradingPeriod" {
-			writeJSON(w, http.StatusOK, map[string]AcademicSession{"academicSession": session})
-			return
+	if !slices.ContainsFunc(visibleOnly(h.Store, h.Store.AcademicSessions), func(s AcademicSession) bool {
+		return s.SourcedId == id && s.Type == "gradingPeriod"
+	}) {
+		writeError(w, http.StatusNotFound, "Grading Period not found")
+		return
+	}
+	var lineItems []LineItem
+	for _, lineItem := range visibleOnly(h.Store, h.Store.LineItems) {
+		if lineItem.GradingPeriod.SourcedId == id {
+			lineItems = append(lineItems, lineItem)
 		}
 	}
-	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Grading Period not found"})
+	writeCollection(w, r, lineItemEnvelope.plural, lineItems)
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// sameJSON reports whether a and b encode the same JSON value.
+func sameJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("decoding %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("decoding %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// TestTypedViews checks that the routes served by typedView answer as the
+// per-type handlers they replaced did: the matching records under the
+// generic envelope keys, and a 404 naming the type for an id that doesn't
+// exist or belongs to a record of another type.
+func TestTypedViews(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	var schools []Org
+	var district string
+	for _, org := range s.store.Orgs {
+		switch org.Type {
+		case "school":
+			schools = append(schools, org)
+		case "district":
+			district = org.SourcedId
+		}
+	}
+	sessionsOf := func(sessionType string) []AcademicSession {
+		var sessions []AcademicSession
+		for _, session := range s.store.AcademicSessions {
+			if session.Type == sessionType {
+				sessions = append(sessions, session)
+			}
+		}
+		return sessions
+	}
+	terms, periods := sessionsOf("term"), sessionsOf("gradingPeriod")
+
+	for _, tc := range []struct {
+		path     string
+		key      string // the plural envelope key
+		single   string // the singular one
+		items    any
+		item     any
+		id       string
+		otherId  string // a record of the same collection but another type
+		notFound string
+	}{
+		{"/schools", "orgs", "org", schools, schools[0], schools[0].SourcedId, district, "School not found"},
+		{"/terms", "academicSessions", "academicSession", terms, terms[0], terms[0].SourcedId, periods[0].SourcedId, "Term not found"},
+		{"/gradingPeriods", "academicSessions", "academicSession", periods, periods[0], periods[0].SourcedId, terms[0].SourcedId, "Grading Period not found"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := s.get(t, tc.path+"?limit=1000")
+			want, _ := json.Marshal(map[string]any{tc.key: tc.items})
+			if rec.Code != http.StatusOK || !sameJSON(t, rec.Body.Bytes(), want) {
+				t.Errorf("GET %s: %d %s, want 200 %s", tc.path, rec.Code, rec.Body, want)
+			}
+
+			rec = s.get(t, tc.path+"/"+tc.id)
+			want, _ = json.Marshal(map[string]any{tc.single: tc.item})
+			if rec.Code != http.StatusOK || !sameJSON(t, rec.Body.Bytes(), want) {
+				t.Errorf("GET %s/%s: %d %s, want 200 %s", tc.path, tc.id, rec.Code, rec.Body, want)
+			}
+
+			want, _ = json.Marshal(map[string]string{"error": tc.notFound})
+			for _, id := range []string{tc.otherId, "missing"} {
+				rec = s.get(t, tc.path+"/"+id)
+				if rec.Code != http.StatusNotFound || !sameJSON(t, rec.Body.Bytes(), want) {
+					t.Errorf("GET %s/%s: %d %s, want 404 %s", tc.path, id, rec.Code, rec.Body, want)
+				}
+			}
+		})
+	}
+}
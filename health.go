@@ -0,0 +1,68 @@
+package main
+
+import (
+	"cmp"
+	"net/http"
+	"time"
+)
+
+// HealthResponse is the body returned by GET /health.
+type HealthResponse struct {
+	Status        string         `json:"status"` // "ok", or "degraded" when a collection is empty
+	UptimeSeconds int64          `json:"uptimeSeconds"`
+	EntityCounts  map[string]int `json:"entityCounts"` // keyed by collection, e.g. "enrollments"
+	// Seed is the seed the data was generated from, or null when it was
+	// loaded from MOCK_DATA_FILE.
+	Seed *uint64 `json:"seed"`
+	// Profile is where the data came from: "file" when it was loaded from
+	// MOCK_DATA_FILE, the MOCK_PROFILE preset it was generated with, such as
+	// "golden", or else "generated".
+	Profile string `json:"profile"`
+}
+
+// health returns the handler of GET /health, which reports the readiness of
+// store for dashboards: how long the server has been up and how many records
+// each collection holds. It answers 200 even when a collection is empty, so
+// monitoring can alert on the counts rather than on the status code.
+// profile is the MOCK_PROFILE preset the store was generated with, if any.
+func health(ds *DataStore, profile string, started time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ds.mu.RLock()
+		counts := entityCounts(ds)
+		ds.mu.RUnlock()
+
+		resp := HealthResponse{
+			Status:        "ok",
+			UptimeSeconds: int64(time.Since(started) / time.Second),
+			EntityCounts:  counts,
+			Profile:       "file",
+		}
+		for _, n := range counts {
+			if n == 0 {
+				resp.Status = "degraded"
+			}
+		}
+		if ds.seed != 0 {
+			resp.Seed = &ds.seed
+			resp.Profile = cmp.Or(profile, "generated")
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// entityCounts returns the number of records in each collection of ds,
+// keyed by collection. The caller must hold ds.mu.
+func entityCounts(ds *DataStore) map[string]int {
+	return map[string]int{
+		orgEnvelope.plural:             len(ds.Orgs),
+		userEnvelope.plural:            len(ds.Users),
+		courseEnvelope.plural:          len(ds.Courses),
+		classEnvelope.plural:           len(ds.Classes),
+		enrollmentEnvelope.plural:      ds.enrollmentCount(),
+		academicSessionEnvelope.plural: len(ds.AcademicSessions),
+		categoryEnvelope.plural:        len(ds.Categories),
+		lineItemEnvelope.plural:        len(ds.LineItems),
+		resultEnvelope.plural:          len(ds.Results),
+		resourceEnvelope.plural:        len(ds.Resources),
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+// TestHealthProfile checks that /health reports the MOCK_PROFILE preset the
+// data was generated with, and "generated" without one.
+func TestHealthProfile(t *testing.T) {
+	golden, err := loadConfig(func(name string) string {
+		if name == "MOCK_PROFILE" {
+			return "golden"
+		}
+		return ""
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		cfg  Config
+		want string
+	}{
+		{golden, "golden"},
+		{testConfig(7), "generated"},
+	} {
+		s := newTestServer(tc.cfg)
+		if got := decode[HealthResponse](t, s.get(t, "/health")).Profile; got != tc.want {
+			t.Errorf("profile %q, want %q", got, tc.want)
+		}
+	}
+}
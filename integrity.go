@@ -0,0 +1,317 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ValidateStore checks the integrity of ds and returns every violation found:
+// GUIDRefs that don't resolve to an existing object, GUIDRefs whose type
+// doesn't match the object they resolve to (a "student" ref to a teacher, a
+// "term" ref to a grading period), objects missing a required field, and
+// students enrolled in a class of another grade.
+// Generated stores are expected to be valid; snapshots loaded from
+// MOCK_DATA_FILE may not be. Enrollments still pending with
+// MOCK_LAZY_ENROLLMENTS are built for the check but left pending. Records
+// chaos mode makes inconsistent on purpose are not violations; checkStore
+// reports them apart.
+func ValidateStore(ds *DataStore) []error {
+	violations, _ := checkStore(ds)
+	return violations
+}
+
+// checkStore returns the violations ValidateStore reports and, apart, those
+// found on records whose metadata marks them as deliberately inconsistent:
+// orgless users, termless classes and orphaned enrollments.
+func checkStore(ds *DataStore) (violations, deliberate []error) {
+	enrollments := ds.enrollmentsUncached()
+	dangling, mismatched := referenceErrors(ds, enrollments)
+	errs := slices.Concat(dangling, mismatched, fieldErrors(ds, enrollments), gradeErrors(ds, enrollments))
+	return partitionDeliberate(errs, deliberateRecords(ds, enrollments))
+}
+
+// partitionDeliberate splits errs into those found on the records of chaos,
+// as returned by deliberateRecords, and the rest.
+func partitionDeliberate(errs []error, chaos map[string]bool) (violations, deliberate []error) {
+	for _, err := range errs {
+		var re recordError
+		if errors.As(err, &re) && chaos[re.id] {
+			deliberate = append(deliberate, err)
+		} else {
+			violations = append(violations, err)
+		}
+	}
+	return violations, deliberate
+}
+
+// recordError is a violation found on the record with sourcedId id.
+type recordError struct {
+	id string
+	error
+}
+
+// recordErrorf formats a violation found on the record with sourcedId id.
+func recordErrorf(id, format string, args ...any) error {
+	return recordError{id: id, error: fmt.Errorf(format, args...)}
+}
+
+// deliberateRecords returns the sourcedIds of the records chaos mode marked
+// as deliberately inconsistent, whether their metadata is as generated or
+// decoded from a snapshot.
+func deliberateRecords(ds *DataStore, enrollments []Enrollment) map[string]bool {
+	ids := make(map[string]bool)
+	for _, user := range ds.Users {
+		if m, ok := user.Metadata.(*UserMetadata); ok && m.Orgless || decodedFlag(user.Metadata, "orgless") {
+			ids[user.SourcedId] = true
+		}
+	}
+	for _, class := range ds.Classes {
+		if m, ok := class.Metadata.(*ClassMetadata); ok && m.Termless || decodedFlag(class.Metadata, "termless") {
+			ids[class.SourcedId] = true
+		}
+	}
+	for _, enrollment := range enrollments {
+		if m, ok := enrollment.Metadata.(*EnrollmentMetadata); ok && m.Orphaned != "" || decodedFlag(enrollment.Metadata, "orphaned") {
+			ids[enrollment.SourcedId] = true
+		}
+	}
+	return ids
+}
+
+// decodedFlag reports whether metadata decoded from JSON sets key to a value
+// other than false or "".
+func decodedFlag(metadata any, key string) bool {
+	m, ok := metadata.(map[string]any)
+	if !ok {
+		return false
+	}
+	value, ok := m[key]
+	return ok && value != false && value != ""
+}
+
+// gradeErrors reports student enrollments in a class none of whose grades
+// the student is in. Students or classes without grades are not checked.
+func gradeErrors(ds *DataStore, enrollments []Enrollment) []error {
+	grades := make(map[string][]string)
+	for _, user := range ds.Users {
+		grades[user.SourcedId] = user.Grades
+	}
+	for _, class := range ds.Classes {
+		grades[class.SourcedId] = class.Grades
+	}
+	var errs []error
+	for i, enrollment := range enrollments {
+		student, class := grades[enrollment.User.SourcedId], grades[enrollment.Class.SourcedId]
+		if enrollment.Role != "student" || len(student) == 0 || len(class) == 0 {
+			continue
+		}
+		if !slices.ContainsFunc(class, func(g string) bool { return slices.Contains(student, g) }) {
+			errs = append(errs, recordErrorf(enrollment.SourcedId, "enrollments[%d] (%s): student in grades %v enrolled in class of grades %v", i, enrollment.SourcedId, student, class))
+		}
+	}
+	return errs
+}
+
+// refTarget resolves GUIDRefs pointing into one collection. kinds maps each
+// sourcedId to the object's subtype (an org's type, a user's role, an
+// academic session's type); a ref's type must be either the collection's
+// generic type or that subtype.
+type refTarget struct {
+	collection string
+	generic    string
+	kinds      map[string]string
+}
+
+// targetOf builds the refTarget of a collection of items.
+func targetOf[T entity](collection, generic string, items []T, kind func(T) string) refTarget {
+	t := refTarget{collection: collection, generic: generic, kinds: make(map[string]string, len(items))}
+	for _, item := range items {
+		t.kinds[item.sourcedID()] = kind(item)
+	}
+	return t
+}
+
+// referenceErrors reports the GUIDRefs in ds, whose enrollments are given,
+// that name no object of the collection they point into, and separately
+// those that resolve to an object of a different type than the ref claims.
+func referenceErrors(ds *DataStore, enrollments []Enrollment) (dangling, mismatched []error) {
+	orgs := targetOf("orgs", "org", ds.Orgs, func(o Org) string { return o.Type })
+	users := targetOf("users", "user", ds.Users, func(u User) string { return u.Role })
+	courses := targetOf("courses", "course", ds.Courses, func(Course) string { return "course" })
+	classes := targetOf("classes", "class", ds.Classes, func(Class) string { return "class" })
+	sessions := targetOf("academicSessions", "academicSession", ds.AcademicSessions, func(s AcademicSession) string { return s.Type })
+	categories := targetOf("categories", "category", ds.Categories, func(Category) string { return "category" })
+	lineItems := targetOf("lineItems", "lineItem", ds.LineItems, func(LineItem) string { return "lineItem" })
+	resources := targetOf("resources", "resource", ds.Resources, func(Resource) string { return "resource" })
+
+	check := func(owner string, i int, id, field string, ref GUIDRef, target refTarget) {
+		kind, ok := target.kinds[ref.SourcedId]
+		switch {
+		case !ok:
+			dangling = append(dangling, recordErrorf(id, "%s[%d] (%s) %s: no %s object has sourcedId %q", owner, i, id, field, target.collection, ref.SourcedId))
+		case ref.Type != target.generic && ref.Type != kind:
+			mismatched = append(mismatched, recordErrorf(id, "%s[%d] (%s) %s: ref of type %q resolves to %s %q of type %q", owner, i, id, field, ref.Type, target.collection, ref.SourcedId, kind))
+		}
+	}
+	for i, org := range ds.Orgs {
+		if org.Parent != nil {
+			check("orgs", i, org.SourcedId, "parent", *org.Parent, orgs)
+		}
+		for _, child := range org.Children {
+			check("orgs", i, org.SourcedId, "children", child, orgs)
+		}
+	}
+	for i, user := range ds.Users {
+		for _, org := range user.Orgs {
+			check("users", i, user.SourcedId, "orgs", org, orgs)
+		}
+	}
+	for i, course := range ds.Courses {
+		if course.SchoolYear != nil {
+			check("courses", i, course.SourcedId, "schoolYear", *course.SchoolYear, sessions)
+		}
+		for _, prerequisite := range course.Prerequisites {
+			check("courses", i, course.SourcedId, "prerequisites", prerequisite, courses)
+		}
+		for _, resource := range course.Resources {
+			check("courses", i, course.SourcedId, "resources", resource, resources)
+		}
+	}
+	for i, class := range ds.Classes {
+		check("classes", i, class.SourcedId, "course", class.Course, courses)
+		check("classes", i, class.SourcedId, "school", class.School, orgs)
+		for _, term := range class.Terms {
+			check("classes", i, class.SourcedId, "terms", term, sessions)
+		}
+		for _, resource := range class.Resources {
+			check("classes", i, class.SourcedId, "resources", resource, resources)
+		}
+	}
+	for i, enrollment := range enrollments {
+		check("enrollments", i, enrollment.SourcedId, "user", enrollment.User, users)
+		check("enrollments", i, enrollment.SourcedId, "class", enrollment.Class, classes)
+		check("enrollments", i, enrollment.SourcedId, "school", enrollment.School, orgs)
+	}
+	for i, session := range ds.AcademicSessions {
+		if session.Parent != nil {
+			check("academicSessions", i, session.SourcedId, "parent", *session.Parent, sessions)
+		}
+		for _, child := range session.Children {
+			check("academicSessions", i, session.SourcedId, "children", child, sessions)
+		}
+	}
+	for i, lineItem := range ds.LineItems {
+		check("lineItems", i, lineItem.SourcedId, "class", lineItem.Class, classes)
+		check("lineItems", i, lineItem.SourcedId, "category", lineItem.Category, categories)
+		check("lineItems", i, lineItem.SourcedId, "gradingPeriod", lineItem.GradingPeriod, sessions)
+	}
+	for i, result := range ds.Results {
+		check("results", i, result.SourcedId, "lineItem", result.LineItem, lineItems)
+		check("results", i, result.SourcedId, "student", result.Student, users)
+	}
+	return dangling, mismatched
+}
+
+// fieldErrors reports objects in ds, whose enrollments are given, that lack
+// a required field, have an unrecognized status, or share their sourcedId
+// with another object of the same collection.
+func fieldErrors(ds *DataStore, enrollments []Enrollment) []error {
+	var errs []error
+	// require takes alternating field names and values.
+	require := func(owner string, i int, id string, fields ...string) {
+		for f := 0; f < len(fields); f += 2 {
+			if fields[f+1] == "" {
+				errs = append(errs, recordErrorf(id, "%s[%d] (%s): missing required field %s", owner, i, id, fields[f]))
+			}
+		}
+	}
+
+	errs = append(errs, baseErrors("orgs", ds.Orgs)...)
+	for i, org := range ds.Orgs {
+		require("orgs", i, org.SourcedId, "name", org.Name, "type", org.Type)
+	}
+	errs = append(errs, baseErrors("users", ds.Users)...)
+	for i, user := range ds.Users {
+		for _, problem := range userFieldErrors(user) {
+			errs = append(errs, recordErrorf(user.SourcedId, "users[%d] (%s): %s", i, user.SourcedId, problem))
+		}
+	}
+	errs = append(errs, baseErrors("courses", ds.Courses)...)
+	for i, course := range ds.Courses {
+		require("courses", i, course.SourcedId, "title", course.Title)
+	}
+	errs = append(errs, baseErrors("classes", ds.Classes)...)
+	for i, class := range ds.Classes {
+		require("classes", i, class.SourcedId, "title", class.Title)
+		if len(class.Terms) == 0 {
+			errs = append(errs, recordErrorf(class.SourcedId, "classes[%d] (%s): missing required field terms", i, class.SourcedId))
+		}
+	}
+	errs = append(errs, baseErrors("enrollments", enrollments)...)
+	for i, enrollment := range enrollments {
+		require("enrollments", i, enrollment.SourcedId, "role", enrollment.Role)
+	}
+	errs = append(errs, baseErrors("academicSessions", ds.AcademicSessions)...)
+	for i, session := range ds.AcademicSessions {
+		require("academicSessions", i, session.SourcedId, "title", session.Title, "type", session.Type, "startDate", session.StartDate, "endDate", session.EndDate)
+	}
+	errs = append(errs, baseErrors("categories", ds.Categories)...)
+	for i, category := range ds.Categories {
+		require("categories", i, category.SourcedId, "title", category.Title)
+	}
+	errs = append(errs, baseErrors("lineItems", ds.LineItems)...)
+	for i, lineItem := range ds.LineItems {
+		require("lineItems", i, lineItem.SourcedId, "title", lineItem.Title)
+	}
+	errs = append(errs, baseErrors("results", ds.Results)...)
+	for i, result := range ds.Results {
+		require("results", i, result.SourcedId, "scoreStatus", result.ScoreStatus)
+	}
+	errs = append(errs, baseErrors("resources", ds.Resources)...)
+	for i, resource := range ds.Resources {
+		require("resources", i, resource.SourcedId, "title", resource.Title, "vendorResourceId", resource.VendorResourceId)
+	}
+	return errs
+}
+
+// userFieldErrors describes what is wrong with the fields of one user: each
+// required field it lacks and each role outside userRoles.
+func userFieldErrors(user User) []string {
+	var problems []string
+	for _, field := range [][2]string{{"username", user.Username}, {"givenName", user.GivenName}, {"familyName", user.FamilyName}, {"role", user.Role}} {
+		if field[1] == "" {
+			problems = append(problems, "missing required field "+field[0])
+		}
+	}
+	if len(user.Orgs) == 0 {
+		problems = append(problems, "missing required field orgs")
+	}
+	for _, role := range append([]string{user.Role}, user.Roles...) {
+		if role != "" && !slices.Contains(userRoles, role) {
+			problems = append(problems, fmt.Sprintf("invalid role %q", role))
+		}
+	}
+	return problems
+}
+
+// baseErrors checks the BaseModel fields of one collection: every object
+// needs a unique sourcedId and a recognized status.
+func baseErrors[T entity](owner string, items []T) []error {
+	var errs []error
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		id := item.sourcedID()
+		switch {
+		case id == "":
+			errs = append(errs, fmt.Errorf("%s[%d]: missing required field sourcedId", owner, i))
+		case seen[id]:
+			errs = append(errs, recordErrorf(id, "%s[%d] (%s): duplicate sourcedId", owner, i, id))
+		}
+		seen[id] = true
+		if !slices.Contains(statuses, item.status()) {
+			errs = append(errs, recordErrorf(id, "%s[%d] (%s): invalid status %q", owner, i, id, item.status()))
+		}
+	}
+	return errs
+}
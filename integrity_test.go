@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// testConfig returns a small generation config for tests under seed.
+func testConfig(seed uint64) Config {
+	cfg := DefaultConfig()
+	cfg.Seed = seed
+	cfg.Schools, cfg.Students, cfg.Teachers, cfg.Courses, cfg.Classes = 4, 200, 20, 10, 60
+	return cfg
+}
+
+// TestValidateStore checks that a generated store is valid and that each
+// kind of deliberate breakage is reported on the record broken.
+func TestValidateStore(t *testing.T) {
+	if errs := ValidateStore(NewDataStore(testConfig(7))); len(errs) > 0 {
+		t.Fatalf("generated store: %d violations, first %v", len(errs), errs[0])
+	}
+
+	for _, tc := range []struct {
+		name       string
+		breakStore func(ds *DataStore) string // breaks ds and returns the violation expected
+	}{
+		{"dangling class course", func(ds *DataStore) string {
+			ds.Classes[0].Course.SourcedId = "missing-course"
+			return `classes[0] (` + ds.Classes[0].SourcedId + `) course: no courses object has sourcedId "missing-course"`
+		}},
+		{"dangling enrollment user", func(ds *DataStore) string {
+			ds.Enrollments[0].User.SourcedId = "missing-user"
+			return `enrollments[0] (` + ds.Enrollments[0].SourcedId + `) user: no users object has sourcedId "missing-user"`
+		}},
+		{"wrong ref type", func(ds *DataStore) string {
+			teacher := ds.Users[len(ds.Users)-1]
+			ds.Results[0].Student = GUIDRef{Href: "/users/" + teacher.SourcedId, SourcedId: teacher.SourcedId, Type: "student"}
+			return `results[0] (` + ds.Results[0].SourcedId + `) student: ref of type "student" resolves to users "` + teacher.SourcedId + `" of type "teacher"`
+		}},
+		{"missing required field", func(ds *DataStore) string {
+			ds.Courses[0].Title = ""
+			return `courses[0] (` + ds.Courses[0].SourcedId + `): missing required field title`
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := NewDataStore(testConfig(7))
+			want := tc.breakStore(ds)
+			var found []string
+			for _, err := range ValidateStore(ds) {
+				found = append(found, err.Error())
+			}
+			if len(found) != 1 || found[0] != want {
+				t.Errorf("ValidateStore:\n%s\nwant:\n%s", strings.Join(found, "\n"), want)
+			}
+		})
+	}
+}
+
+// TestValidateStoreChaos checks that the records chaos mode makes
+// inconsistent on purpose are reported apart from violations.
+func TestValidateStoreChaos(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.Chaos = true
+	violations, deliberate := checkStore(NewDataStore(cfg))
+	if len(violations) > 0 {
+		t.Errorf("chaos store: violations %v", violations)
+	}
+	if len(deliberate) == 0 {
+		t.Error("chaos store: no deliberate inconsistencies found")
+	}
+}
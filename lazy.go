@@ -0,0 +1,257 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Generation deals students and teachers to classes first and builds the
+// enrollment records from the dealt seats last. Dealing is cheap; building
+// hashes an id and formats dates and hrefs for every record, and the records
+// take about ten times the memory of the seats. With MOCK_LAZY_ENROLLMENTS
+// the store keeps the seats and builds the enrollments of a class or user on
+// first access, caching them, or all of them at once the first time a
+// request needs the whole collection: listing, lookup by sourcedId, any
+// write, export and snapshots. Either way the records are built from the
+// same seats in the same order, so they are identical to those built eagerly
+// under the same seed.
+
+// enrollmentSeat is one dealt enrollment not yet built.
+type enrollmentSeat struct {
+	class   int32 // index into pendingEnrollments.classes
+	classId string
+	user    string
+	teacher bool
+	primary bool
+	// orphan is set on the enrollments chaos mode adds with a missing user
+	// or class, to "user" or "class"; they copy seat copyOf, with user or
+	// classId replaced by the missing one.
+	orphan string
+	copyOf int32
+	// transfer is "out" or "in" on the enrollments of a transferred
+	// student; see addTransfers.
+	transfer string
+	modified time.Time
+}
+
+// pendingClass is what building an enrollment needs of its class, as
+// generated: later writes to the class don't change its enrollments.
+type pendingClass struct {
+	id         string
+	school     GUIDRef
+	begin, end time.Time
+}
+
+// pendingEnrollments holds the seats of a generated store whose enrollments
+// are still to be built. Once done, DataStore.Enrollments holds them all.
+type pendingEnrollments struct {
+	// mu guards everything below. Reads of the store share ds.mu, so
+	// building under it needs a lock of its own.
+	mu        sync.Mutex
+	done      bool
+	namespace uuid.UUID
+	classes   []pendingClass
+	seats     []enrollmentSeat
+	byClass   map[string][]int32
+	byUser    map[string][]int32
+	built     map[int32]Enrollment
+}
+
+// add deals a seat of user in class.
+func (p *pendingEnrollments) add(class int, user string, teacher, primary bool) {
+	p.seats = append(p.seats, enrollmentSeat{class: int32(class), user: user, teacher: teacher, primary: primary, classId: p.classes[class].id})
+}
+
+// addOrphan deals a copy of seat n whose user or class, as named by
+// reference, is replaced by missing.
+func (p *pendingEnrollments) addOrphan(n int, reference, missing string) {
+	seat := p.seats[n]
+	seat.orphan, seat.copyOf = reference, int32(n)
+	if reference == "user" {
+		seat.user = missing
+	} else {
+		seat.classId = missing
+	}
+	p.seats = append(p.seats, seat)
+}
+
+// index records which seats belong to which class and user. It runs once
+// dealing is done.
+func (p *pendingEnrollments) index() {
+	p.byClass = make(map[string][]int32)
+	p.byUser = make(map[string][]int32)
+	for i, seat := range p.seats {
+		p.byClass[seat.classId] = append(p.byClass[seat.classId], int32(i))
+		p.byUser[seat.user] = append(p.byUser[seat.user], int32(i))
+	}
+	p.built = make(map[int32]Enrollment)
+}
+
+// build returns the enrollment of the n-th seat. Enrollments span their
+// class's term. For variety in date-range logic, one student enrollment in
+// twelve starts two to five weeks late and one in fifteen ends three to five
+// weeks early; both shifts are far shorter than a term, so BeginDate always
+// stays before EndDate.
+func (p *pendingEnrollments) build(n int) Enrollment {
+	seat := p.seats[n]
+	if seat.orphan != "" {
+		enrollment := p.build(int(seat.copyOf))
+		if seat.orphan == "user" {
+			enrollment.User = GUIDRef{Href: "/users/" + seat.user, SourcedId: seat.user, Type: "user"}
+		} else {
+			enrollment.Class = GUIDRef{Href: "/classes/" + seat.classId, SourcedId: seat.classId, Type: "class"}
+		}
+		enrollment.SourcedId = uuid.NewSHA1(p.namespace, []byte("enrollment:"+seat.classId+":"+seat.user)).String()
+		enrollment.DateLastModified = seat.modified
+		enrollment.Metadata = &EnrollmentMetadata{Orphaned: seat.orphan, Transfer: seat.transfer}
+		return enrollment
+	}
+	class := p.classes[seat.class]
+	begin, end := class.begin, class.end
+	role := "teacher"
+	if !seat.teacher {
+		role = "student"
+		if n%12 == 5 {
+			begin = begin.AddDate(0, 0, 14+n%21)
+		}
+		if n%15 == 7 {
+			end = end.AddDate(0, 0, -(21 + n%14))
+		}
+	}
+	var metadata any
+	switch left, joined := transferDates(class.begin); seat.transfer {
+	case "out":
+		end = left
+		metadata = &EnrollmentMetadata{Transfer: seat.transfer}
+	case "in":
+		begin = joined
+		metadata = &EnrollmentMetadata{Transfer: seat.transfer}
+	}
+	return Enrollment{
+		BaseModel: BaseModel{SourcedId: uuid.NewSHA1(p.namespace, []byte("enrollment:"+class.id+":"+seat.user)).String(), Status: "active", DateLastModified: seat.modified, Metadata: metadata},
+		User:      GUIDRef{Href: "/users/" + seat.user, SourcedId: seat.user, Type: "user"},
+		Class:     GUIDRef{Href: "/classes/" + class.id, SourcedId: class.id, Type: "class"},
+		School:    class.school,
+		Role:      role,
+		Primary:   seat.primary,
+		BeginDate: begin.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+	}
+}
+
+// buildSeats returns the enrollments of the given seats, building each on
+// first use. The caller must hold p.mu.
+func (p *pendingEnrollments) buildSeats(seats []int32) []Enrollment {
+	enrollments := make([]Enrollment, 0, len(seats))
+	for _, n := range seats {
+		enrollment, ok := p.built[n]
+		if !ok {
+			enrollment = p.build(int(n))
+			p.built[n] = enrollment
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	return enrollments
+}
+
+// buildEach returns every enrollment, building those not built yet without
+// keeping them. The caller must hold p.mu.
+func (p *pendingEnrollments) buildEach() []Enrollment {
+	enrollments := make([]Enrollment, len(p.seats))
+	for n := range p.seats {
+		if enrollment, ok := p.built[int32(n)]; ok {
+			enrollments[n] = enrollment
+		} else {
+			enrollments[n] = p.build(n)
+		}
+	}
+	return enrollments
+}
+
+// buildAll returns every enrollment and releases the seats. The caller must
+// hold p.mu.
+func (p *pendingEnrollments) buildAll() []Enrollment {
+	enrollments := p.buildEach()
+	p.done = true
+	p.classes, p.seats, p.byClass, p.byUser, p.built = nil, nil, nil, nil, nil
+	return enrollments
+}
+
+// enrollments returns every enrollment, first building those still pending.
+// The caller must hold ds.mu, and every read or write of ds.Enrollments must
+// go through here or through enrollmentsOfClass and enrollmentsOfUser.
+func (ds *DataStore) enrollments() []Enrollment {
+	if p := ds.pending; p != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.done {
+			ds.Enrollments = p.buildAll()
+		}
+	}
+	return ds.Enrollments
+}
+
+// enrollmentsUncached returns every enrollment as enrollments does, but
+// builds those still pending without keeping them, so that a one-off pass
+// over all of them, such as ValidateStore, leaves them pending. The caller
+// must hold ds.mu.
+func (ds *DataStore) enrollmentsUncached() []Enrollment {
+	if p := ds.pending; p != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.done {
+			return p.buildEach()
+		}
+	}
+	return ds.Enrollments
+}
+
+// enrollmentsOfClass returns the enrollments in the class with the given
+// sourcedId, building only those if they are still pending. The caller must
+// hold ds.mu.
+func (ds *DataStore) enrollmentsOfClass(id string) []Enrollment {
+	return ds.enrollmentsOf(func(p *pendingEnrollments) []int32 { return p.byClass[id] },
+		func(e Enrollment) bool { return e.Class.SourcedId == id })
+}
+
+// enrollmentsOfUser returns the enrollments of the user with the given
+// sourcedId, building only those if they are still pending. The caller must
+// hold ds.mu.
+func (ds *DataStore) enrollmentsOfUser(id string) []Enrollment {
+	return ds.enrollmentsOf(func(p *pendingEnrollments) []int32 { return p.byUser[id] },
+		func(e Enrollment) bool { return e.User.SourcedId == id })
+}
+
+// enrollmentsOf returns the pending seats picks, built, or else the
+// enrollments that match, in store order either way.
+func (ds *DataStore) enrollmentsOf(seats func(*pendingEnrollments) []int32, match func(Enrollment) bool) []Enrollment {
+	if p := ds.pending; p != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.done {
+			return p.buildSeats(seats(p))
+		}
+	}
+	var enrollments []Enrollment
+	for _, enrollment := range ds.Enrollments {
+		if match(enrollment) {
+			enrollments = append(enrollments, enrollment)
+		}
+	}
+	return enrollments
+}
+
+// enrollmentCount returns how many enrollments the store holds, built or
+// not. The caller must hold ds.mu.
+func (ds *DataStore) enrollmentCount() int {
+	if p := ds.pending; p != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.done {
+			return len(p.seats)
+		}
+	}
+	return len(ds.Enrollments)
+}
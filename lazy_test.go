@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestLazyEnrollmentsMatchEager checks that lazy enrollments are built the
+// same as eager ones and that the integrity check finds the same problems
+// either way, with chaos mode seeding some.
+func TestLazyEnrollmentsMatchEager(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.Chaos = true
+	eager := NewDataStore(cfg)
+	cfg.LazyEnrollments = true
+	lazy := NewDataStore(cfg)
+
+	findings := func(ds *DataStore) []string {
+		violations, deliberate := checkStore(ds)
+		var messages []string
+		for _, err := range slices.Concat(violations, deliberate) {
+			messages = append(messages, err.Error())
+		}
+		return messages
+	}
+	eagerFindings, lazyFindings := findings(eager), findings(lazy)
+	if len(eagerFindings) == 0 || !slices.Equal(eagerFindings, lazyFindings) {
+		t.Errorf("checkStore: eager finds %q, lazy finds %q", eagerFindings, lazyFindings)
+	}
+	if lazy.pending.done {
+		t.Error("checkStore built the lazy enrollments for good")
+	}
+
+	// Generation stamps dateLastModified relative to the time it runs.
+	withoutDates := func(enrollments []Enrollment) []Enrollment {
+		enrollments = slices.Clone(enrollments)
+		for i := range enrollments {
+			enrollments[i].DateLastModified = time.Time{}
+		}
+		return enrollments
+	}
+	class := eager.Classes[0].SourcedId
+	if got, want := withoutDates(lazy.enrollmentsOfClass(class)), withoutDates(eager.enrollmentsOfClass(class)); !reflect.DeepEqual(got, want) {
+		t.Errorf("enrollmentsOfClass(%s): lazy %v, eager %v", class, got, want)
+	}
+	if got, want := withoutDates(lazy.enrollments()), withoutDates(eager.enrollments()); !reflect.DeepEqual(got, want) {
+		t.Errorf("enrollments: lazy and eager differ (%d vs %d records)", len(got), len(want))
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// maxLookupBatch caps how many sourcedIds a single bulk lookup may carry.
+const maxLookupBatch = 1000
+
+// LookupRequest is the body accepted by the bulk lookup endpoints.
+// @Description A batch of sourcedIds to resolve in a single request.
+type LookupRequest struct {
+	SourcedIds []string `json:"sourcedIds"`
+}
+
+// lookupByIds decodes a LookupRequest from the body and writes the items whose
+// SourcedId was requested under the given envelope key. Results follow the
+// order of the requested ids; unknown and repeated ids are skipped.
+func lookupByIds[T entity](w http.ResponseWriter, r *http.Request, items []T, key string) {
+	var req LookupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if len(req.SourcedIds) > maxLookupBatch {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many sourcedIds: at most %d are allowed per request", maxLookupBatch))
+		return
+	}
+
+	byId := make(map[string]T, len(items))
+	for _, item := range items {
+		byId[item.sourcedID()] = item
+	}
+	var matched []T
+	for _, id := range req.SourcedIds {
+		if item, ok := byId[id]; ok {
+			matched = append(matched, item)
+			delete(byId, id)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string][]T{key: matched})
+}
+
+// lookupUsers handles bulk lookups of users by SourcedId.
+// @Summary Look up users by sourcedId
+// @Description Resolves up to 1000 user sourcedIds in one request. Unknown ids are skipped.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body LookupRequest true "SourcedIds to resolve"
+// @Success 200 {object} map[string][]User
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /users/lookup [post]
+func (h *APIHandlers) lookupUsers(w http.ResponseWriter, r *http.Request) {
+	lookupByIds(w, r, visibleOnly(h.Store, h.Store.Users), userEnvelope.plural)
+}
+
+// lookupClasses handles bulk lookups of classes by SourcedId.
+// @Summary Look up classes by sourcedId
+// @Description Resolves up to 1000 class sourcedIds in one request. Unknown ids are skipped.
+// @Tags Classes
+// @Accept json
+// @Produce json
+// @Param request body LookupRequest true "SourcedIds to resolve"
+// @Success 200 {object} map[string][]Class
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /classes/lookup [post]
+func (h *APIHandlers) lookupClasses(w http.ResponseWriter, r *http.Request) {
+	lookupByIds(w, r, visibleOnly(h.Store, h.Store.Classes), classEnvelope.plural)
+}
+
+// lookupEnrollments handles bulk lookups of enrollments by SourcedId.
+// @Summary Look up enrollments by sourcedId
+// @Description Resolves up to 1000 enrollment sourcedIds in one request. Unknown ids are skipped.
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Param request body LookupRequest true "SourcedIds to resolve"
+// @Success 200 {object} map[string][]Enrollment
+// @Failure 400 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /enrollments/lookup [post]
+func (h *APIHandlers) lookupEnrollments(w http.ResponseWriter, r *http.Request) {
+	lookupByIds(w, r, visibleOnly(h.Store, h.Store.enrollments()), enrollmentEnvelope.plural)
+}
@@ -3,14 +3,10 @@ package main
 import (
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
-	"time"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
-	httpSwagger "github.com/swaggo/http-swagger"
-	_ "go-oneroster-mock/docs" // Import generated docs
+	"go-oneroster-mock/docs"
 )
 
 // @title OneRoster Mock API
@@ -35,89 +31,87 @@ import (
 // --------------------------------------------------
 
 func main() {
-	log.Println("Generating mock data store...")
-	store := NewDataStore()
-	log.Printf("Data generation complete. %d users, %d orgs, %d classes loaded.", len(store.Users), len(store.Orgs), len(store.Classes))
-
-	handlers := &APIHandlers{Store: store}
-
-	r := chi.NewRouter()
-
-	// --- Middleware ---
-	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
-
-	// CORS for frontend development
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:5100"}, // Add your C# dev server port if needed
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
-
-	// --- Mock Authentication Middleware ---
-	r.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Swagger UI assets don't need auth
-			if strings.HasPrefix(r.URL.Path, "/swagger/") {
-				next.ServeHTTP(w, r)
-				return
-			}
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				http.Error(w, "Unauthorized: Missing Authorization header", http.StatusUnauthorized)
-				return
-			}
-			next.ServeHTTP(w, r)
-		})
-	})
-
-	// --- API Routes ---
-	r.Route("/ims/oneroster/v1p1", func(r chi.Router) {
-		// Orgs & Schools
-		r.Get("/orgs", handlers.getOrgs)
-		r.Get("/orgs/{id}", handlers.getOrg)
-		r.Get("/schools", handlers.getSchools)
-		r.Get("/schools/{id}", handlers.getSchool)
-
-		// Users, Teachers, Students
-		r.Get("/users", handlers.getUsers)
-		r.Get("/users/{id}", handlers.getUser)
-		r.Get("/teachers", handlers.getTeachers)
-		r.Get("/teachers/{id}", handlers.getTeacher)
-		r.Get("/students", handlers.getStudents)
-		r.Get("/students/{id}", handlers.getStudent)
-
-		// Courses & Classes
-		r.Get("/courses", handlers.getCourses)
-		r.Get("/courses/{id}", handlers.getCourse)
-		r.Get("/classes", handlers.getClasses)
-		r.Get("/classes/{id}", handlers.getClass)
-		r.Get("/classes/{id}/categories", handlers.getCategoriesForClass)
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, warning := range cfg.Warnings {
+		log.Printf("Warning: %s", warning)
+	}
 
-		// Enrollments
-		r.Get("/enrollments", handlers.getEnrollments)
-		r.Get("/enrollments/{id}", handlers.getEnrollment)
+	var store *DataStore
+	if cfg.DataFile != "" {
+		log.Printf("Loading mock data store from %s...", cfg.DataFile)
+		if store, err = LoadDataStore(cfg.DataFile); err != nil {
+			log.Fatalf("Failed to load data file: %v", err)
+		}
+	} else {
+		log.Println("Generating mock data store...")
+		if cfg.Profile != "" {
+			log.Printf("Using the %s profile (seed %d).", cfg.Profile, cfg.Seed)
+		}
+		store = NewDataStore(cfg)
+	}
+	log.Printf("Data store ready. %d users, %d orgs, %d classes, %d enrollments, %d line items, %d results loaded.",
+		len(store.Users), len(store.Orgs), len(store.Classes), store.enrollmentCount(), len(store.LineItems), len(store.Results))
+	for _, warning := range store.warnings {
+		log.Printf("Warning: %s", warning)
+	}
 
-		// Academic Sessions, Terms, Grading Periods
-		r.Get("/terms", handlers.getTerms)
-		r.Get("/terms/{id}", handlers.getTerm)
-		r.Get("/academicSessions", handlers.getAcademicSessions)
-		r.Get("/academicSessions/{id}", handlers.getAcademicSession)
-		r.Get("/gradingPeriods", handlers.getGradingPeriods)
-		r.Get("/gradingPeriods/{id}", handlers.getGradingPeriod)
-	})
+	if violations := ValidateStore(store); len(violations) > 0 {
+		for _, violation := range violations {
+			log.Printf("Integrity violation: %v", violation)
+		}
+		if cfg.Strict {
+			log.Fatalf("Found %d integrity violation(s); refusing to start with MOCK_STRICT=true.", len(violations))
+		}
+		log.Printf("Found %d integrity violation(s); starting anyway.", len(violations))
+	}
 
-	// --- Swagger UI Route ---
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	if cfg.SimulatedClock {
+		log.Println("Using a simulated clock; advance it with POST /admin/clock/advance.")
+	}
+	if cfg.LazyEnrollments && cfg.DataFile == "" {
+		log.Println("Generated enrollments are built on first access, a class or user at a time.")
+	}
+	if cfg.SessionTimeZone != nil {
+		log.Printf("Academic session dates are timestamps in UTC offset %s.", cfg.SessionTimeZone)
+	}
+	if cfg.Chaos {
+		log.Println("Chaos mode: some users share an email or username; see collidesWith in their metadata. Some have awkward names; see nameEdgeCase. Some have no orgs; see orgless. Some classes have no terms; see termless in their metadata. Some enrollments reference a missing user or class; see orphaned in their metadata. Some classes and courses lack optional fields; see omitted in their metadata.")
+	}
+	if cfg.WriteVisibilityDelay > 0 {
+		log.Printf("Write visibility delay set to %s.", cfg.WriteVisibilityDelay)
+	}
+	if len(cfg.DisabledEndpoints) > 0 {
+		log.Printf("Not implementing: %s", strings.Join(cfg.DisabledEndpoints, ", "))
+	}
+	if len(cfg.DownEntities) > 0 {
+		log.Printf("Simulating an outage of: %s", strings.Join(cfg.DownEntities, ", "))
+	}
+	if cfg.SnakeCase {
+		log.Println("Serving snake_case keys instead of the spec's camelCase.")
+	}
+	if cfg.EmptyNotFound {
+		log.Println("Empty collections answer 404 instead of 200.")
+	}
+	if cfg.UnstableOrder {
+		log.Println("Collections without an explicit sort are shuffled on every request.")
+	}
+	if cfg.RateLimit > 0 {
+		log.Printf("Rate limiting each client to %g requests per second with bursts of %d.", cfg.RateLimit, cfg.RateBurst)
+	}
+	if cfg.Latency > 0 || cfg.ErrorRate > 0 {
+		log.Printf("Simulating %s latency and a %.0f%% error rate.", cfg.Latency, cfg.ErrorRate*100)
+	}
+	if cfg.TTFBDelay > 0 {
+		log.Printf("Delaying the first byte of every response body by %s.", cfg.TTFBDelay)
+	}
 
-	log.Println("Starting server on :5100...")
-	if err := http.ListenAndServe(":5100", r); err != nil {
+	docs.SwaggerInfo.BasePath = cfg.BasePath
+	addr := ":" + strconv.Itoa(cfg.Port)
+	log.Printf("Starting server on %s...", addr)
+	if err := http.ListenAndServe(addr, NewRouter(store, cfg)); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// defaultMaintenanceMessage and defaultMaintenanceRetryAfter (in seconds)
+// are used when POST /admin/maintenance doesn't give its own.
+const (
+	defaultMaintenanceMessage    = "The service is undergoing scheduled maintenance"
+	defaultMaintenanceRetryAfter = 300
+)
+
+// maintenanceMode emulates a provider maintenance window: while it is on,
+// every OneRoster route answers 503 Service Unavailable. Admin routes are
+// not affected, so the window can be closed again.
+type maintenanceMode struct {
+	mu    sync.RWMutex
+	state MaintenanceRequest
+}
+
+// MaintenanceRequest is the body accepted, and echoed back, by POST
+// /admin/maintenance.
+type MaintenanceRequest struct {
+	Enabled    bool   `json:"enabled"`
+	Message    string `json:"message"`
+	RetryAfter int    `json:"retryAfter"` // seconds
+}
+
+// middleware rejects requests with the configured 503 while maintenance is on.
+func (m *maintenanceMode) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.RLock()
+		state := m.state
+		m.mu.RUnlock()
+		if state.Enabled {
+			w.Header().Set("Retry-After", strconv.Itoa(state.RetryAfter))
+			writeStatusInfo(w, http.StatusServiceUnavailable, "server_busy", state.Message)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// post handles requests to open or close the maintenance window. A missing
+// message or retryAfter falls back to the defaults.
+func (m *maintenanceMode) post(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if req.RetryAfter < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid retryAfter: must be a non-negative number of seconds")
+		return
+	}
+	if req.Message == "" {
+		req.Message = defaultMaintenanceMessage
+	}
+	if req.RetryAfter == 0 {
+		req.RetryAfter = defaultMaintenanceRetryAfter
+	}
+	m.mu.Lock()
+	m.state = req
+	m.mu.Unlock()
+	writeJSON(w, http.StatusOK, req)
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// entityAliases maps the type-scoped collections onto the entity they are a
+// view of, so taking an entity down also takes down its typed views.
+var entityAliases = map[string]string{
+	"schools":        "orgs",
+	"teachers":       "users",
+	"students":       "users",
+	"terms":          "academicSessions",
+	"gradingPeriods": "academicSessions",
+}
+
+// outageRetryAfter is the Retry-After value, in seconds, sent with simulated
+// outage responses.
+const outageRetryAfter = 30
+
+// routeEntity returns the collection a request under basePath addresses: the
+// last collection segment of the path, so /classes/{id}/results is "results"
+// and /users/{id} is "users". A segment is a collection if it is the plural
+// key of an envelope or one of its entityAliases; any other segment after an
+// id, such as capacity, metadata or descendants, is a view of the record
+// before it, so /classes/{id}/capacity is "classes".
+func routeEntity(basePath, path string) string {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, basePath), "/"), "/")
+	entity := segments[0]
+	for i := 2; i < len(segments); i += 2 {
+		if isCollection(segments[i]) {
+			entity = segments[i]
+		}
+	}
+	return entity
+}
+
+// isCollection reports whether a path segment names a collection.
+func isCollection(segment string) bool {
+	_, aliased := entityAliases[segment]
+	return aliased || slices.ContainsFunc(envelopes, func(e envelope) bool { return e.plural == segment })
+}
+
+// collections returns the names isCollection accepts, sorted.
+func collections() []string {
+	var names []string
+	for _, e := range envelopes {
+		names = append(names, e.plural)
+	}
+	for alias := range entityAliases {
+		names = append(names, alias)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// partialOutage returns middleware that makes requests for the given entities
+// fail with 503 Service Unavailable, emulating one subsystem of a provider
+// being down while the rest keep working. Entities are named by their route
+// collection, e.g. "results" or "lineItems"; naming "users" also takes down
+// /teachers and /students, while naming "teachers" takes down only that view.
+func partialOutage(basePath string, down []string) func(http.Handler) http.Handler {
+	isDown := make(map[string]bool, len(down))
+	for _, entity := range down {
+		isDown[entity] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entity := routeEntity(basePath, r.URL.Path)
+			if isDown[entity] || isDown[entityAliases[entity]] {
+				w.Header().Set("Retry-After", strconv.Itoa(outageRetryAfter))
+				writeStatusInfo(w, http.StatusServiceUnavailable, "server_busy", "The "+entity+" service is temporarily unavailable")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// degraded returns middleware that delays every request by latency and fails
+// the given fraction of them with 500 Internal Server Error, emulating a slow
+// and unreliable provider.
+func degraded(latency time.Duration, errorRate float64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !sleep(r.Context(), latency) {
+				return
+			}
+			if rand.Float64() < errorRate {
+				writeStatusInfo(w, http.StatusInternalServerError, "internal_server_error", "Simulated provider failure")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ttfbDelayHeader reports the delay ttfbDelay applied, in milliseconds.
+const ttfbDelayHeader = "X-Mock-TTFB-Delay-Ms"
+
+// ttfbDelay returns middleware that sends the headers of each response
+// straight away but holds back its body for delay, emulating a provider that
+// is slow to start streaming and fast after. Unlike degraded, which delays
+// the whole response, it targets clients that time out on the first byte.
+// The response is buffered until the handler returns, so that the delay runs
+// after readLocked has released the store and doesn't hold up writers.
+func ttfbDelay(delay time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(ttfbDelayHeader, strconv.FormatInt(delay.Milliseconds(), 10))
+			bw := &bufferedWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, r)
+			bw.flush(r.Context(), delay)
+		})
+	}
+}
+
+// bufferedWriter holds back a response until the handler has returned, for
+// middleware that sends it only after the handler's lock or delay is over.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (bw *bufferedWriter) WriteHeader(status int) {
+	if bw.status == 0 {
+		bw.status = status
+	}
+}
+
+func (bw *bufferedWriter) Write(b []byte) (int, error) {
+	bw.WriteHeader(http.StatusOK)
+	return bw.body.Write(b)
+}
+
+// flush sends the buffered headers and then the buffered body, after delay
+// if it is positive. A response without a body is sent without waiting, and
+// nothing is sent if the handler wrote nothing.
+func (bw *bufferedWriter) flush(ctx context.Context, delay time.Duration) {
+	if bw.status == 0 {
+		return
+	}
+	bw.ResponseWriter.WriteHeader(bw.status)
+	if bw.body.Len() == 0 {
+		return
+	}
+	if delay > 0 {
+		http.NewResponseController(bw.ResponseWriter).Flush()
+		if !sleep(ctx, delay) {
+			return
+		}
+	}
+	bw.ResponseWriter.Write(bw.body.Bytes())
+}
+
+// requestTimeout returns middleware that gives each request a deadline of
+// timeout. A handler that gives up on its expired context without writing
+// anything gets a 504 Gateway Timeout with a OneRoster error body, where
+// chi's middleware.Timeout would send an empty one.
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			tw := &timeoutWriter{ResponseWriter: w}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			if ctx.Err() == context.DeadlineExceeded && !tw.started {
+				writeStatusInfo(w, http.StatusGatewayTimeout, "server_busy", fmt.Sprintf("Request timed out after %s", timeout))
+			}
+		})
+	}
+}
+
+// timeoutWriter records whether a response was started, so requestTimeout
+// knows whether it can still send its own.
+type timeoutWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.started = true
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.started = true
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+// sleep waits for d, or until ctx is done, and reports whether the full
+// duration passed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// requireTrailingSlash returns middleware that emulates a provider serving
+// routes under basePath only with a trailing slash: "/users/" is routed as
+// "/users", while "/users" gets a 404. The request URL keeps its slash, so
+// Link headers point at the slash form too.
+func requireTrailingSlash(basePath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.Path
+			if !strings.HasPrefix(path, basePath+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !strings.HasSuffix(path, "/") {
+				writeError(w, http.StatusNotFound, "No route matches "+path+"; this server only serves paths with a trailing slash, e.g. "+path+"/")
+				return
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				rctx.RoutePath = strings.TrimSuffix(path, "/")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireJSON returns middleware that rejects POST, PUT and PATCH requests
+// whose Content-Type isn't application/json with 415 Unsupported Media Type,
+// before a handler reports a confusing parse error. Parameters such as
+// charset=utf-8 are allowed.
+func requireJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			contentType := r.Header.Get("Content-Type")
+			if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+				writeStatusInfo(w, http.StatusUnsupportedMediaType, "invalid_data", fmt.Sprintf("Unsupported Content-Type %q: request bodies must be application/json", contentType))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// extraHeaders returns middleware that sets the given headers on every
+// response, emulating a provider's proprietary headers.
+func extraHeaders(headers http.Header) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for name, values := range headers {
+				w.Header()[name] = values
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type unstableOrderKey struct{}
+
+// unstableOrder returns middleware that makes writeCollection shuffle every
+// collection that isn't explicitly sorted, afresh on each request, emulating
+// a provider whose default order changes between pages. Explicit sort and
+// cursor paging stay stable.
+func unstableOrder(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), unstableOrderKey{}, true)))
+	})
+}
+
+type emptyNotFoundKey struct{}
+
+// emptyNotFound returns middleware that makes writeCollection answer 404 Not
+// Found when no items are left after filtering, emulating a provider that
+// treats an empty collection as missing. The spec answers 200 with an empty
+// array.
+func emptyNotFound(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), emptyNotFoundKey{}, true)))
+	})
+}
+
+// readLocked returns middleware that holds the store's lock shared while
+// the handler of every request that doesn't write runs. PUT, PATCH and
+// DELETE handlers take the lock exclusively themselves; POST is only used
+// for read-only lookups. The response is buffered and sent once the lock is
+// released, so a slow client doesn't hold up writers.
+func readLocked(ds *DataStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut, http.MethodPatch, http.MethodDelete:
+				next.ServeHTTP(w, r)
+				return
+			}
+			bw := &bufferedWriter{ResponseWriter: w}
+			func() {
+				ds.mu.RLock()
+				defer ds.mu.RUnlock()
+				next.ServeHTTP(bw, r)
+			}()
+			bw.flush(r.Context(), 0)
+		})
+	}
+}
+
+// serverTiming returns middleware that reports how long the server spent on
+// each request in a W3C Server-Timing header, as a "total" metric in
+// milliseconds. Headers must be sent before the body, so the duration covers
+// the handler up to the moment it starts its response.
+func serverTiming(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&timingWriter{ResponseWriter: w, start: time.Now()}, r)
+	})
+}
+
+// timingWriter sets the Server-Timing header when the response starts.
+type timingWriter struct {
+	http.ResponseWriter
+	start   time.Time
+	written bool
+}
+
+func (tw *timingWriter) WriteHeader(status int) {
+	if !tw.written {
+		tw.written = true
+		elapsed := float64(time.Since(tw.start).Microseconds()) / 1000
+		tw.Header().Set("Server-Timing", fmt.Sprintf("total;dur=%.3f", elapsed))
+	}
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *timingWriter) Write(b []byte) (int, error) {
+	if !tw.written {
+		tw.WriteHeader(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (tw *timingWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRouteEntity(t *testing.T) {
+	for path, want := range map[string]string{
+		"/users":                                     "users",
+		"/users/u1":                                  "users",
+		"/users/lookup":                              "users",
+		"/users/u1/metadata":                         "users",
+		"/users/u1/enrollments":                      "enrollments",
+		"/classes/c1/capacity":                       "classes",
+		"/classes/c1/students":                       "students",
+		"/classes/c1/students/u1/results":            "results",
+		"/orgs/o1/descendants":                       "orgs",
+		"/students/u1/transcript":                    "students",
+		"/enrollments/e1/related":                    "enrollments",
+		"/gradingPeriods/{id}/lineItems":             "lineItems",
+		"/ims/oneroster/v1p1/classes/c1/capacity":    "classes",
+		"/ims/oneroster/v1p1/schools/o1/teachers/t1": "teachers",
+	} {
+		if got := routeEntity("/ims/oneroster/v1p1", path); got != want {
+			t.Errorf("routeEntity(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// TestPartialOutageViews checks that taking an entity down also takes down
+// the views of its records.
+func TestPartialOutageViews(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.DownEntities = []string{"classes", "users"}
+	s := newTestServer(cfg)
+	class, user := s.store.Classes[0].SourcedId, s.store.Users[0].SourcedId
+	for _, path := range []string{"/classes/" + class + "/capacity", "/classes/" + class + "/metadata", "/users/" + user + "/metadata"} {
+		if rec := s.get(t, path); rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("GET %s: %d, want 503", path, rec.Code)
+		}
+	}
+	if rec := s.get(t, "/classes/"+class+"/results"); rec.Code != http.StatusOK {
+		t.Errorf("GET /classes/{id}/results: %d, want 200", rec.Code)
+	}
+}
+
+// stalledWriter is a client that stops reading once the response body
+// starts, until released.
+type stalledWriter struct {
+	*httptest.ResponseRecorder
+	once     sync.Once
+	stalled  chan struct{}
+	released chan struct{}
+}
+
+func (sw *stalledWriter) Write(b []byte) (int, error) {
+	sw.once.Do(func() { close(sw.stalled) })
+	<-sw.released
+	return sw.ResponseRecorder.Write(b)
+}
+
+// TestReadLockedSlowClient checks that a read whose client is slow to take
+// its response doesn't hold the store lock against a write.
+func TestReadLockedSlowClient(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	sw := &stalledWriter{ResponseRecorder: httptest.NewRecorder(), stalled: make(chan struct{}), released: make(chan struct{})}
+	req := httptest.NewRequest(http.MethodGet, s.cfg.BasePath+"/users", nil)
+	req.Header.Set("Authorization", "Bearer test")
+	done := make(chan struct{})
+	go func() {
+		s.handler.ServeHTTP(sw, req)
+		close(done)
+	}()
+	<-sw.stalled
+
+	patched := make(chan int)
+	go func() {
+		patched <- s.do(t, http.MethodPatch, "/users/"+s.store.Users[0].SourcedId, `{"user": {"givenName": "Changed"}}`, nil).Code
+	}()
+	select {
+	case code := <-patched:
+		if code != http.StatusOK {
+			t.Errorf("PATCH during a stalled read: %d, want 200", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("PATCH blocked behind a stalled read")
+	}
+	close(sw.released)
+	<-done
+}
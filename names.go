@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// localeNames are the given and family names drawn on for generated users
+// in each non-English locale. They are deliberately non-ASCII, with
+// accents, kana and kanji, Cyrillic and Arabic script, to exercise a
+// consumer's UTF-8 handling.
+var localeNames = map[string]struct{ given, family []string }{
+	"es": {
+		given:  []string{"José", "María", "Íñigo", "Begoña", "Ángel", "Lucía", "Martín", "Sofía", "Raúl", "Noemí"},
+		family: []string{"Núñez", "Muñoz", "Peña", "Gómez", "Ibáñez", "Rodríguez", "Fernández", "Sánchez", "Martínez", "Álvarez", "López"},
+	},
+	"ja": {
+		given:  []string{"さくら", "ひろし", "陽翔", "結衣", "蓮", "美咲", "大翔", "葵", "悠真", "ゆうな"},
+		family: []string{"佐藤", "鈴木", "高橋", "田中", "渡辺", "伊藤", "山本", "中村", "小林", "加藤", "ヤマダ"},
+	},
+	"ru": {
+		given:  []string{"Алексей", "Мария", "Дмитрий", "Анастасия", "Иван", "Екатерина", "Сергей", "Ольга", "Никита", "Юлия"},
+		family: []string{"Иванов", "Смирнова", "Кузнецов", "Попова", "Васильев", "Петрова", "Соколов", "Михайлова", "Новиков", "Фёдорова", "Морозов"},
+	},
+	"ar": {
+		given:  []string{"محمد", "فاطمة", "أحمد", "مريم", "علي", "نور", "يوسف", "سارة", "عمر", "ليلى"},
+		family: []string{"العلي", "الحسن", "الخطيب", "النجار", "الحداد", "السيد", "القاسم", "الشامي", "المصري", "الزين", "البكري"},
+	},
+}
+
+// locales lists the values MOCK_LOCALE accepts.
+var locales = []string{"en", "es", "ja", "ru", "ar"}
+
+// personName returns the given and family name of the i-th generated user
+// with the given role. English names are the ASCII "Student"/"User<i>"
+// pattern; other locales cycle through lists of unequal length, so names
+// combine differently from one user to the next.
+func personName(locale, role string, i int) (given, family string) {
+	names, ok := localeNames[locale]
+	if !ok {
+		if role == "student" {
+			return "Student", fmt.Sprintf("User%d", i)
+		}
+		return "Teacher", fmt.Sprintf("User%d", i)
+	}
+	return names.given[i%len(names.given)], names.family[i%len(names.family)]
+}
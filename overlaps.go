@@ -0,0 +1,87 @@
+package main
+
+import "net/http"
+
+// SessionOverlap is a pair of academic sessions whose date ranges overlap,
+// both ends inclusive. First is the one that starts earlier, or the longer
+// of two that start together.
+type SessionOverlap struct {
+	First  GUIDRef `json:"first"`
+	Second GUIDRef `json:"second"`
+	// Contains is set when First's range covers all of Second's.
+	Contains bool `json:"contains"`
+	// Nested is set when one of the two is an ancestor of the other, so the
+	// overlap is expected. Overlapping siblings or cousins are not nested.
+	Nested bool `json:"nested"`
+}
+
+// SessionOverlapsResponse is the body returned by GET /admin/sessions/overlaps.
+type SessionOverlapsResponse struct {
+	Overlaps []SessionOverlap `json:"overlaps"`
+}
+
+// getSessionOverlaps handles requests for every pair of academic sessions
+// whose date ranges overlap, for testing calendar logic that must not assume
+// sessions are disjoint. Sessions without both dates are left out.
+func (h *APIHandlers) getSessionOverlaps(w http.ResponseWriter, r *http.Request) {
+	var sessions []AcademicSession
+	for _, session := range visibleOnly(h.Store, h.Store.AcademicSessions) {
+		if session.StartDate != "" && session.EndDate != "" {
+			sessions = append(sessions, session)
+		}
+	}
+	parents := make(map[string]string, len(sessions))
+	for _, session := range sessions {
+		if session.Parent != nil {
+			parents[session.SourcedId] = session.Parent.SourcedId
+		}
+	}
+
+	overlaps := []SessionOverlap{}
+	for i, a := range sessions {
+		for _, b := range sessions[i+1:] {
+			// Dates are YYYY-MM-DD, so they compare as strings.
+			if a.StartDate > b.EndDate || b.StartDate > a.EndDate {
+				continue
+			}
+			if b.StartDate < a.StartDate || b.StartDate == a.StartDate && b.EndDate > a.EndDate {
+				a, b = b, a
+			}
+			overlaps = append(overlaps, SessionOverlap{
+				First:    sessionRef(a),
+				Second:   sessionRef(b),
+				Contains: a.EndDate >= b.EndDate,
+				Nested:   isAncestor(parents, a.SourcedId, b.SourcedId) || isAncestor(parents, b.SourcedId, a.SourcedId),
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, SessionOverlapsResponse{Overlaps: overlaps})
+}
+
+// isAncestor reports whether ancestor is reached by following parents up
+// from id. A cycle in the hierarchy ends the walk.
+func isAncestor(parents map[string]string, ancestor, id string) bool {
+	seen := map[string]bool{id: true}
+	for {
+		parent, ok := parents[id]
+		if !ok || seen[parent] {
+			return false
+		}
+		if parent == ancestor {
+			return true
+		}
+		seen[parent] = true
+		id = parent
+	}
+}
+
+// sessionRef returns a reference to session under the endpoint of its type.
+func sessionRef(session AcademicSession) GUIDRef {
+	switch session.Type {
+	case "term":
+		return GUIDRef{Href: "/terms/" + session.SourcedId, SourcedId: session.SourcedId, Type: "term"}
+	case "gradingPeriod":
+		return GUIDRef{Href: "/gradingPeriods/" + session.SourcedId, SourcedId: session.SourcedId, Type: "gradingPeriod"}
+	}
+	return GUIDRef{Href: "/academicSessions/" + session.SourcedId, SourcedId: session.SourcedId, Type: "academicSession"}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// statusOverrides make single records fail on demand, for targeted error
+// testing: once POST /admin/override sets a status for a record, every GET
+// of that record answers with that status and a OneRoster error body, while
+// the rest of its collection keeps working. A GET is of the record when its
+// first two path segments are the record's collection, or one of its typed
+// views such as /students, and its sourcedId, so /users/<id>/enrollments
+// fails along with /users/<id>. Overrides last until DELETE
+// /admin/override clears them.
+type statusOverrides struct {
+	mu    sync.RWMutex
+	byKey map[string]StatusOverride // by entityType and sourcedId
+}
+
+// StatusOverride is the body accepted, and echoed back, by POST
+// /admin/override, and an entry of the list GET /admin/override returns.
+type StatusOverride struct {
+	EntityType string `json:"entityType"` // an envelope singular, e.g. "user"
+	SourcedId  string `json:"sourcedId"`
+	Status     int    `json:"status"` // an HTTP error status, 400 to 599
+}
+
+// overrideKey is the key of the override of the record of entityType with
+// the given sourcedId.
+func overrideKey(entityType, sourcedId string) string {
+	return entityType + ":" + sourcedId
+}
+
+// middleware answers GETs of overridden records with their status.
+func (o *statusOverrides) middleware(basePath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, basePath), "/"), "/")
+			if r.Method != http.MethodGet || len(segments) < 2 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			collection := cmp.Or(entityAliases[segments[0]], segments[0])
+			i := slices.IndexFunc(envelopes, func(e envelope) bool { return e.plural == collection })
+			if i < 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			o.mu.RLock()
+			override, ok := o.byKey[overrideKey(envelopes[i].singular, segments[1])]
+			o.mu.RUnlock()
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeStatusInfo(w, override.Status, overrideCodeMinor(override.Status),
+				fmt.Sprintf("Simulated %d %s for %s %s", override.Status, http.StatusText(override.Status), override.EntityType, override.SourcedId))
+		})
+	}
+}
+
+// overrideCodeMinor returns the OneRoster minor code that best describes an
+// error status.
+func overrideCodeMinor(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return "unauthorisedrequest"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "unknown_object"
+	case http.StatusNotImplemented:
+		return "unsupported"
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return "server_busy"
+	}
+	if status >= 500 {
+		return "internal_server_error"
+	}
+	return "invalid_data"
+}
+
+// post handles requests to make a record fail with a status, replacing any
+// status set for it before. The record need not exist yet, so that a
+// record can be made to fail before a test creates it.
+func (o *statusOverrides) post(w http.ResponseWriter, r *http.Request) {
+	var req StatusOverride
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if _, ok := envelopeBySingular(req.EntityType); !ok {
+		writeError(w, http.StatusBadRequest, invalidEntityType(req.EntityType))
+		return
+	}
+	if req.SourcedId == "" {
+		writeError(w, http.StatusBadRequest, "sourcedId is required")
+		return
+	}
+	if req.Status < 400 || req.Status > 599 {
+		writeError(w, http.StatusBadRequest, "Invalid status "+strconv.Itoa(req.Status)+": must be an error status from 400 to 599")
+		return
+	}
+	o.mu.Lock()
+	if o.byKey == nil {
+		o.byKey = make(map[string]StatusOverride)
+	}
+	o.byKey[overrideKey(req.EntityType, req.SourcedId)] = req
+	o.mu.Unlock()
+	writeJSON(w, http.StatusOK, req)
+}
+
+// list handles requests for the active overrides, ordered by entityType and
+// sourcedId.
+func (o *statusOverrides) list(w http.ResponseWriter, r *http.Request) {
+	o.mu.RLock()
+	overrides := make([]StatusOverride, 0, len(o.byKey))
+	for _, override := range o.byKey {
+		overrides = append(overrides, override)
+	}
+	o.mu.RUnlock()
+	slices.SortFunc(overrides, func(a, b StatusOverride) int {
+		return cmp.Or(cmp.Compare(a.EntityType, b.EntityType), cmp.Compare(a.SourcedId, b.SourcedId))
+	})
+	writeJSON(w, http.StatusOK, map[string][]StatusOverride{"overrides": overrides})
+}
+
+// remove handles requests to clear overrides: the one of the record named
+// by the entityType and sourcedId query parameters, or every one without
+// them.
+func (o *statusOverrides) remove(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if !query.Has("entityType") && !query.Has("sourcedId") {
+		o.mu.Lock()
+		o.byKey = nil
+		o.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	key := overrideKey(query.Get("entityType"), query.Get("sourcedId"))
+	o.mu.Lock()
+	_, ok := o.byKey[key]
+	delete(o.byKey, key)
+	o.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "No override for "+query.Get("entityType")+" "+strconv.Quote(query.Get("sourcedId")))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Offset paging reads the live collection on every page, so a write between
+// pages can shift records across page boundaries: a record inserted or
+// removed before the current offset makes the next page repeat or skip one.
+// Cursor paging (after) avoids that for inserts and removals; pinned pages
+// avoid it altogether. A first page requested with consistentSnapshot=true
+// saves the collection as filtered and sorted for it and returns a token in
+// the X-Snapshot-Token header. Pages requested with snapshotToken=<token>
+// read from that frozen view, ignoring later writes and their own filter and
+// sort parameters, until it expires pageSnapshotTTL after it was last read
+// by the store's clock.
+
+const (
+	// pageSnapshotTTL is how long a pinned view outlives its last read.
+	pageSnapshotTTL = 5 * time.Minute
+	// maxPageSnapshots caps how many pinned views are retained; pinning
+	// another one discards the one closest to expiring.
+	maxPageSnapshots = 100
+	// snapshotTokenHeader carries the token of a pinned view.
+	snapshotTokenHeader = "X-Snapshot-Token"
+)
+
+// pageSnapshot is one pinned view of a collection.
+type pageSnapshot struct {
+	key     string // the envelope key of the collection, e.g. "users"
+	items   any    // the []T the first page was cut from
+	expires time.Time
+}
+
+// pageSnapshotStore holds the pinned views of every collection.
+type pageSnapshotStore struct {
+	// now is the store's clock, which expiry follows, so that a simulated
+	// clock can be advanced past pageSnapshotTTL.
+	now   func() time.Time
+	mu    sync.Mutex
+	saved map[string]*pageSnapshot
+}
+
+// save pins items, the view of the collection under key, and returns its
+// token.
+func (s *pageSnapshotStore) save(key string, items any, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saved == nil {
+		s.saved = make(map[string]*pageSnapshot)
+	}
+	for token, snapshot := range s.saved {
+		if now.After(snapshot.expires) {
+			delete(s.saved, token)
+		}
+	}
+	if len(s.saved) >= maxPageSnapshots {
+		oldest := ""
+		for token, snapshot := range s.saved {
+			if oldest == "" || snapshot.expires.Before(s.saved[oldest].expires) {
+				oldest = token
+			}
+		}
+		delete(s.saved, oldest)
+	}
+	token := uuid.New().String()
+	s.saved[token] = &pageSnapshot{key: key, items: items, expires: now.Add(pageSnapshotTTL)}
+	return token
+}
+
+// load returns the pinned view with the given token and extends its life,
+// or false if there is none or it expired.
+func (s *pageSnapshotStore) load(token string, now time.Time) (*pageSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot, ok := s.saved[token]
+	if !ok || now.After(snapshot.expires) {
+		delete(s.saved, token)
+		return nil, false
+	}
+	snapshot.expires = now.Add(pageSnapshotTTL)
+	return snapshot, true
+}
+
+type pageSnapshotsKey struct{}
+
+// withPageSnapshots returns middleware that makes snapshots available to
+// writeCollection for pinned pages.
+func withPageSnapshots(snapshots *pageSnapshotStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), pageSnapshotsKey{}, snapshots)))
+		})
+	}
+}
+
+// pinnedItems applies the consistentSnapshot and snapshotToken parameters
+// to items, the collection under key as filtered and sorted for this
+// request. It returns the items to page through and the request to build
+// Link headers from, which carries the token once a view is pinned. When it
+// reports false it has written an error response.
+func pinnedItems[T entity](w http.ResponseWriter, r *http.Request, key string, items []T) ([]T, *http.Request, bool) {
+	snapshots, _ := r.Context().Value(pageSnapshotsKey{}).(*pageSnapshotStore)
+	query := r.URL.Query()
+	if snapshots == nil || !query.Has("consistentSnapshot") && !query.Has("snapshotToken") {
+		return items, r, true
+	}
+	if query.Has("after") {
+		writeError(w, http.StatusBadRequest, "consistentSnapshot and snapshotToken cannot be combined with after")
+		return nil, nil, false
+	}
+	if query.Has("snapshotToken") {
+		token := query.Get("snapshotToken")
+		snapshot, ok := snapshots.load(token, snapshots.now())
+		if !ok {
+			writeError(w, http.StatusGone, "Snapshot "+strconv.Quote(token)+" is unknown or expired; start again with consistentSnapshot=true")
+			return nil, nil, false
+		}
+		pinned, ok := snapshot.items.([]T)
+		if !ok || snapshot.key != key {
+			writeError(w, http.StatusBadRequest, "Snapshot "+strconv.Quote(token)+" belongs to "+snapshot.key+", not "+key)
+			return nil, nil, false
+		}
+		w.Header().Set(snapshotTokenHeader, token)
+		return pinned, r, true
+	}
+	pin, err := strconv.ParseBool(query.Get("consistentSnapshot"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid consistentSnapshot value: must be true or false")
+		return nil, nil, false
+	}
+	if !pin {
+		return items, r, true
+	}
+	// Writes replace records rather than modify them in place, so a copy of
+	// the slice is enough to freeze the view.
+	items = slices.Clone(items)
+	token := snapshots.save(key, items, snapshots.now())
+	w.Header().Set(snapshotTokenHeader, token)
+	query.Del("consistentSnapshot")
+	query.Set("snapshotToken", token)
+	u := *r.URL
+	u.RawQuery = query.Encode()
+	r = r.WithContext(r.Context())
+	r.URL = &u
+	return items, r, true
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Query parameter validation.
+//
+// Every route declares the query parameters it understands. A request that
+// carries any other parameter is rejected with a 400 naming it, rather than
+// having the parameter silently ignored, so a client never believes a filter
+// applied when it did not. For example /teachers?role=student is rejected
+// because /teachers is already scoped to teachers and has no role parameter.
+
+// collectionParams are understood by every collection route; they are applied
+// by writeCollection.
+var collectionParams = []string{"status", "showDeleted", "modifiedSince", "filter", "sort", "orderBy", "limit", "offset", "after", "envelope", "idsOnly", "consistentSnapshot", "snapshotToken", "fields"}
+
+// acceptQuery returns middleware that rejects requests with query parameters
+// outside params.
+func acceptQuery(params ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var unsupported []string
+			for name := range r.URL.Query() {
+				if !slices.Contains(params, name) {
+					unsupported = append(unsupported, name)
+				}
+			}
+			if len(unsupported) > 0 {
+				sort.Strings(unsupported)
+				supported := "none"
+				if len(params) > 0 {
+					supported = strings.Join(params, ", ")
+				}
+				writeError(w, http.StatusBadRequest, "Unsupported query parameter(s) for "+r.URL.Path+": "+
+					strings.Join(unsupported, ", ")+" (supported: "+supported+")")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// collectionQuery is acceptQuery for a collection route, which understands
+// the shared collection parameters in addition to its own.
+func collectionQuery(params ...string) func(http.Handler) http.Handler {
+	return acceptQuery(append(params, collectionParams...)...)
+}
+
+// exclusiveQuery returns middleware that rejects requests carrying more than
+// one of params, for parameters whose combination would be ambiguous.
+func exclusiveQuery(params ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var given []string
+			for _, name := range params {
+				if r.URL.Query().Has(name) {
+					given = append(given, name)
+				}
+			}
+			if len(given) > 1 {
+				writeError(w, http.StatusBadRequest, "Query parameters "+strings.Join(given, " and ")+
+					" cannot be combined on "+r.URL.Path+"; supply only one")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
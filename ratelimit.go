@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// bucket is the token bucket of one client.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimit returns middleware that gives each client, identified by its
+// Authorization header, a token bucket refilled at rate tokens per second
+// and holding at most burst. A request spends one token; with none left it
+// is refused with 429 Too Many Requests and a Retry-After header telling
+// when the next token arrives. Every response carries X-RateLimit-Limit
+// (the burst) and X-RateLimit-Remaining.
+func rateLimit(rate float64, burst int) func(http.Handler) http.Handler {
+	var mu sync.Mutex
+	buckets := make(map[string]*bucket)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+			mu.Lock()
+			b, ok := buckets[r.Header.Get("Authorization")]
+			if !ok {
+				b = &bucket{tokens: float64(burst), last: now}
+				buckets[r.Header.Get("Authorization")] = b
+			}
+			b.tokens = min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+			b.last = now
+			allowed := b.tokens >= 1
+			if allowed {
+				b.tokens--
+			}
+			tokens := b.tokens
+			mu.Unlock()
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(tokens)))
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil((1-tokens)/rate))))
+				writeStatusInfo(w, http.StatusTooManyRequests, "server_busy", "Rate limit exceeded for this client; retry later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
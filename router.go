@@ -0,0 +1,306 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	httpSwagger "github.com/swaggo/http-swagger"
+	"go-oneroster-mock/docs"
+)
+
+// NewRouter builds the complete HTTP handler of the mock server over store.
+// main serves it on a port; tests can drive it with httptest instead.
+func NewRouter(store *DataStore, cfg Config) http.Handler {
+	// The store settings of cfg are applied here rather than by main, so a
+	// router built by a test or an embedder honours them too.
+	if cfg.SimulatedClock {
+		store.SimulateClock(time.Now())
+	}
+	if cfg.WriteVisibilityDelay > 0 {
+		store.SetWriteVisibilityDelay(cfg.WriteVisibilityDelay)
+	}
+	handlers := &APIHandlers{Store: store, RequireIfMatch: cfg.RequireIfMatch}
+	report := newGenerationReport(store, cfg)
+	maintenance := &maintenanceMode{}
+	overrides := &statusOverrides{}
+	pages := &pageSnapshotStore{now: store.now}
+
+	r := chi.NewRouter()
+
+	// --- Middleware ---
+	r.Use(serverTiming)
+	r.Use(middleware.RequestID)
+	r.Use(middleware.RealIP)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(requestTimeout(cfg.RequestTimeout))
+
+	// Resolve "/users/" the same as "/users". Swagger UI is excluded because
+	// its index lives at "/swagger/" and must keep the trailing slash. With
+	// MOCK_TRAILING_SLASH only the "/users/" form of OneRoster routes is
+	// served instead.
+	if cfg.TrailingSlash {
+		r.Use(requireTrailingSlash(cfg.BasePath))
+	} else {
+		r.Use(middleware.Maybe(middleware.StripSlashes, func(r *http.Request) bool {
+			return !strings.HasPrefix(r.URL.Path, "/swagger/")
+		}))
+	}
+
+	// CORS for frontend development. Credentials can't be allowed together
+	// with the "*" wildcard origin.
+	exposed := []string{"Link", "X-Total-Count", "Server-Timing", "Retry-After", "Warning", "X-RateLimit-Limit", "X-RateLimit-Remaining", "ETag", ttfbDelayHeader, snapshotTokenHeader}
+	for name := range cfg.ExtraHeaders {
+		exposed = append(exposed, name)
+	}
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   cfg.CORSOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match"},
+		ExposedHeaders:   exposed,
+		AllowCredentials: !slices.Contains(cfg.CORSOrigins, "*"),
+		MaxAge:           300,
+	}))
+
+	// --- Mock Authentication Middleware ---
+	// See auth.go for how tokens and scopes are checked.
+	r.Use(authenticate(cfg.tokenScopes()))
+
+	// --- API Routes ---
+	r.Route(cfg.BasePath, func(r chi.Router) {
+		if len(cfg.DisabledEndpoints) > 0 {
+			r = withoutEndpoints(r, cfg.DisabledEndpoints)
+		}
+		if len(cfg.ExtraHeaders) > 0 {
+			r.Use(extraHeaders(cfg.ExtraHeaders))
+		}
+		if cfg.TTFBDelay > 0 {
+			r.Use(ttfbDelay(cfg.TTFBDelay))
+		}
+		if cfg.SnakeCase {
+			r.Use(snakeCaseKeys)
+		}
+		r.Use(maintenance.middleware)
+		if cfg.RateLimit > 0 {
+			r.Use(rateLimit(cfg.RateLimit, cfg.RateBurst))
+		}
+		if cfg.Latency > 0 || cfg.ErrorRate > 0 {
+			r.Use(degraded(cfg.Latency, cfg.ErrorRate))
+		}
+		if len(cfg.DownEntities) > 0 {
+			r.Use(partialOutage(cfg.BasePath, cfg.DownEntities))
+		}
+		r.Use(overrides.middleware(cfg.BasePath))
+		r.Use(requireScope(cfg.BasePath))
+		r.Use(requireJSON)
+		r.Use(readLocked(store))
+		r.Use(withPageSnapshots(pages))
+		if cfg.UnstableOrder {
+			r.Use(unstableOrder)
+		}
+		if cfg.EmptyNotFound {
+			r.Use(emptyNotFound)
+		}
+
+		// Each route lists the query parameters it accepts; see params.go.
+		// Routes of disabled endpoints are registered as not implemented.
+
+		// Orgs & Schools
+		r.With(collectionQuery()).Get("/orgs", handlers.getOrgs)
+		r.With(acceptQuery()).Get("/orgs/{id}", handlers.getOrg)
+		r.With(collectionQuery()).Get("/orgs/{id}/descendants", handlers.getOrgDescendants)
+		r.With(collectionQuery()).Get("/schools", handlers.getSchools)
+		r.With(acceptQuery()).Get("/schools/{id}", handlers.getSchool)
+		r.With(acceptQuery()).Put("/schools/{id}/classes", handlers.putClassesForSchool)
+		r.With(collectionQuery()).Get("/schools/{id}/teachers", handlers.getTeachersForSchool)
+		r.With(collectionQuery()).Get("/schools/{id}/students", handlers.getStudentsForSchool)
+
+		// Users, Teachers, Students
+		r.With(collectionQuery("role", "orgSourcedId")).Get("/users", handlers.getUsers)
+		r.With(acceptQuery()).Get("/users/{id}", handlers.getUser)
+		r.With(acceptQuery()).Patch("/users/{id}", handlers.patchUser)
+		r.With(acceptQuery()).Get("/users/{id}/metadata", handlers.getUserMetadata)
+		r.With(collectionQuery()).Get("/users/{id}/enrollments", handlers.getEnrollmentsForUser)
+		r.With(acceptQuery()).Post("/users/lookup", handlers.lookupUsers)
+		r.With(collectionQuery("orgSourcedId")).Get("/teachers", handlers.getTeachers)
+		r.With(acceptQuery()).Get("/teachers/{id}", handlers.getTeacher)
+		r.With(collectionQuery("termSourcedId")).Get("/teachers/{id}/classes", handlers.getClassesForTeacher)
+		r.With(collectionQuery("orgSourcedId")).Get("/students", handlers.getStudents)
+		r.With(acceptQuery()).Get("/students/{id}", handlers.getStudent)
+		r.With(collectionQuery("termSourcedId")).Get("/students/{id}/classes", handlers.getClassesForStudent)
+		r.With(collectionQuery()).Get("/students/{id}/classmates", handlers.getClassmatesForStudent)
+		r.With(acceptQuery()).Get("/students/{id}/transcript", handlers.getTranscript)
+
+		// Courses & Classes
+		r.With(collectionQuery("schoolYear", "search")).Get("/courses", handlers.getCourses)
+		r.With(acceptQuery()).Get("/courses/{id}", handlers.getCourse)
+		r.With(collectionQuery()).Get("/courses/{id}/prerequisites", handlers.getPrerequisitesForCourse)
+		r.With(collectionQuery("teacherSourcedId", "studentSourcedId", "include", "search"), exclusiveQuery("teacherSourcedId", "studentSourcedId")).Get("/classes", handlers.getClasses)
+		r.With(acceptQuery("include")).Get("/classes/{id}", handlers.getClass)
+		r.With(acceptQuery()).Get("/classes/{id}/metadata", handlers.getClassMetadata)
+		r.With(acceptQuery()).Get("/classes/{id}/capacity", handlers.getClassCapacity)
+		r.With(acceptQuery()).Post("/classes/lookup", handlers.lookupClasses)
+		r.With(collectionQuery("minWeight")).Get("/classes/{id}/categories", handlers.getCategoriesForClass)
+		r.With(collectionQuery()).Get("/classes/{id}/teachers", handlers.getTeachersForClass)
+		r.With(collectionQuery("grade")).Get("/classes/{id}/students", handlers.getStudentsForClass)
+		r.With(collectionQuery()).Get("/classes/{id}/academicSessions", handlers.getAcademicSessionsForClass)
+
+		// Categories
+		r.With(collectionQuery("minWeight")).Get("/categories", handlers.getCategories)
+
+		// Resources
+		r.With(collectionQuery("role", "importance")).Get("/resources", handlers.getResources)
+		r.With(acceptQuery()).Get("/resources/{id}", handlers.getResource)
+		r.With(collectionQuery("role", "importance")).Get("/classes/{id}/resources", handlers.getResourcesForClass)
+
+		// Line Items
+		r.With(collectionQuery("classSourcedId", "gradingPeriodSourcedId")).Get("/lineItems", handlers.getLineItems)
+		r.With(acceptQuery()).Get("/lineItems/{id}", handlers.getLineItem)
+
+		// Results
+		r.With(collectionQuery("scoreStatus", "expand")).Get("/results", handlers.getResults)
+		r.With(acceptQuery()).Get("/results/{id}", handlers.getResult)
+		r.With(collectionQuery("expand")).Get("/students/{id}/results", handlers.getResultsForStudent)
+		r.With(collectionQuery()).Get("/classes/{id}/results", handlers.getResultsForClass)
+		r.With(collectionQuery()).Get("/classes/{id}/students/{studentId}/results", handlers.getResultsForStudentForClass)
+
+		// Enrollments
+		r.With(collectionQuery("role", "classSourcedId", "primary", "lifecycleState")).Get("/enrollments", handlers.getEnrollments)
+		r.With(acceptQuery()).Get("/enrollments/{id}", handlers.getEnrollment)
+		r.With(acceptQuery()).Put("/enrollments/{id}", handlers.putEnrollment)
+		r.With(acceptQuery()).Get("/enrollments/{id}/related", handlers.getEnrollmentRelated)
+		r.With(acceptQuery()).Post("/enrollments/lookup", handlers.lookupEnrollments)
+
+		// Academic Sessions, Terms, Grading Periods
+		r.With(collectionQuery()).Get("/terms", handlers.getTerms)
+		r.With(acceptQuery()).Get("/terms/{id}", handlers.getTerm)
+		r.With(collectionQuery()).Get("/terms/{id}/classes", handlers.getClassesForTerm)
+		r.With(collectionQuery()).Get("/academicSessions", handlers.getAcademicSessions)
+		r.With(acceptQuery()).Get("/academicSessions/{id}", handlers.getAcademicSession)
+		r.With(collectionQuery()).Get("/academicSessions/{id}/classes", handlers.getClassesForAcademicSession)
+		r.With(collectionQuery()).Get("/gradingPeriods", handlers.getGradingPeriods)
+		r.With(acceptQuery()).Get("/gradingPeriods/{id}", handlers.getGradingPeriod)
+		r.With(collectionQuery()).Get("/gradingPeriods/{id}/lineItems", handlers.getLineItemsForGradingPeriod)
+	})
+
+	// Paths are case-sensitive, as in the OneRoster spec, so "/Users" is not
+	// "/users". Unknown paths get a JSON 404 that says so instead of chi's
+	// plain-text default.
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "No route matches "+r.URL.Path+"; paths are case-sensitive (e.g. /academicSessions, not /AcademicSessions)")
+	})
+
+	// --- Admin Routes ---
+	r.With(readLocked(store)).Get("/admin/export/json", handlers.exportJSON)
+	r.With(readLocked(store)).Get("/admin/highwater", handlers.getHighwater)
+	r.With(readLocked(store)).Get("/admin/aggregate", handlers.getAggregate)
+	r.With(readLocked(store)).Get("/admin/sessions/overlaps", handlers.getSessionOverlaps)
+	r.Get("/admin/schema", handlers.getSchema)
+	r.Get("/admin/genreport", genReport(report))
+	r.Get("/admin/changes", handlers.getChanges)
+	r.Post("/admin/clock/advance", handlers.postClockAdvance)
+	r.Post("/admin/snapshot", handlers.postSnapshot)
+	r.Post("/admin/restore/{id}", handlers.postRestore)
+	r.Post("/admin/maintenance", maintenance.post)
+	r.Get("/admin/override", overrides.list)
+	r.Post("/admin/override", overrides.post)
+	r.Delete("/admin/override", overrides.remove)
+	r.Post("/admin/generate", handlers.postGenerate)
+	r.Post("/admin/delete", handlers.postDelete)
+	r.With(readLocked(store)).Post("/admin/diff", handlers.postDiff)
+
+	// --- Health Route ---
+	r.Get("/health", health(store, cfg.Profile, time.Now()))
+
+	// --- OAuth Routes ---
+	r.Post("/oauth/introspect", introspect(cfg.tokenScopes()))
+
+	// --- Swagger UI Route ---
+	r.Get("/swagger/*", httpSwagger.WrapHandler)
+
+	// --- Raw Spec Route ---
+	// Serves the generated document that backs the Swagger UI, so contract
+	// tests can diff against it without scraping the UI. Despite the path it
+	// is Swagger 2.0 (OpenAPI 2.0), as swag generates it, not OpenAPI 3:
+	// tools reading it must accept a "swagger": "2.0" document.
+	r.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(docs.SwaggerInfo.ReadDoc()))
+	})
+
+	return r
+}
+
+// capabilityRouter registers the routes of disabled entities with
+// notImplemented in place of their handler and middleware, so that they
+// answer 501 Not Implemented whatever the query, emulating a provider that
+// doesn't offer them. Entities are named by their route collection, as in
+// partialOutage.
+type capabilityRouter struct {
+	chi.Router
+	disabled    map[string]bool
+	middlewares []func(http.Handler) http.Handler // from With, for enabled routes
+}
+
+// withoutEndpoints returns r with the routes of the given entities disabled.
+func withoutEndpoints(r chi.Router, entities []string) chi.Router {
+	disabled := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		disabled[entity] = true
+	}
+	return capabilityRouter{Router: r, disabled: disabled}
+}
+
+func (r capabilityRouter) With(middlewares ...func(http.Handler) http.Handler) chi.Router {
+	r.middlewares = append(slices.Clip(r.middlewares), middlewares...)
+	return r
+}
+
+func (r capabilityRouter) Get(pattern string, h http.HandlerFunc) {
+	router, h := r.route(pattern, h)
+	router.Get(pattern, h)
+}
+
+func (r capabilityRouter) Post(pattern string, h http.HandlerFunc) {
+	router, h := r.route(pattern, h)
+	router.Post(pattern, h)
+}
+
+func (r capabilityRouter) Put(pattern string, h http.HandlerFunc) {
+	router, h := r.route(pattern, h)
+	router.Put(pattern, h)
+}
+
+func (r capabilityRouter) Patch(pattern string, h http.HandlerFunc) {
+	router, h := r.route(pattern, h)
+	router.Patch(pattern, h)
+}
+
+func (r capabilityRouter) Delete(pattern string, h http.HandlerFunc) {
+	router, h := r.route(pattern, h)
+	router.Delete(pattern, h)
+}
+
+// route returns the router and handler to register the route pattern with:
+// notImplemented if it addresses a disabled entity, or else h behind the
+// middlewares given to With.
+func (r capabilityRouter) route(pattern string, h http.HandlerFunc) (chi.Router, http.HandlerFunc) {
+	entity := routeEntity("", pattern)
+	if r.disabled[entity] || r.disabled[entityAliases[entity]] {
+		return r.Router, notImplemented(entity)
+	}
+	return r.Router.With(r.middlewares...), h
+}
+
+// notImplemented returns a handler that answers 501 Not Implemented for the
+// routes of entity.
+func notImplemented(entity string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeStatusInfo(w, http.StatusNotImplemented, "unsupported", "This provider does not implement "+entity)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testServer serves a store generated from cfg through NewRouter.
+type testServer struct {
+	store   *DataStore
+	handler http.Handler
+	cfg     Config
+}
+
+func newTestServer(cfg Config) *testServer {
+	store := NewDataStore(cfg)
+	return &testServer{store: store, handler: NewRouter(store, cfg), cfg: cfg}
+}
+
+// do sends a request to path, which is under the base path unless it is an
+// admin route or /health, and returns the recorded response.
+func (s *testServer) do(t *testing.T, method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	if !strings.HasPrefix(path, "/admin/") && path != "/health" {
+		path = s.cfg.BasePath + path
+	}
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Authorization", "Bearer test")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *testServer) get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	return s.do(t, http.MethodGet, path, "", nil)
+}
+
+// decode unmarshals the body of rec into a value of type T.
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decoding %s: %v", rec.Body, err)
+	}
+	return v
+}
+
+// TestUserETagRoundTrip checks that the ETag served by GET /users/{id} and
+// its typed views is the one PATCH requires under MOCK_REQUIRE_IF_MATCH.
+func TestUserETagRoundTrip(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.RequireIfMatch = true
+	s := newTestServer(cfg)
+	student := s.store.Users[0]
+	for _, path := range []string{"/users/" + student.SourcedId, "/students/" + student.SourcedId} {
+		if etag := s.get(t, path).Header().Get("ETag"); etag != entityTag(student) {
+			t.Errorf("GET %s: ETag %q, want %q", path, etag, entityTag(student))
+		}
+	}
+
+	patch := `{"user": {"givenName": "Changed"}}`
+	if rec := s.do(t, http.MethodPatch, "/users/"+student.SourcedId, patch, nil); rec.Code != http.StatusPreconditionRequired {
+		t.Errorf("PATCH without If-Match: status %d, want 428", rec.Code)
+	}
+	etag := s.get(t, "/users/"+student.SourcedId).Header().Get("ETag")
+	rec := s.do(t, http.MethodPatch, "/users/"+student.SourcedId, patch, http.Header{"If-Match": {etag}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH with the ETag from GET: status %d: %s", rec.Code, rec.Body)
+	}
+	if rec := s.do(t, http.MethodPatch, "/users/"+student.SourcedId, patch, http.Header{"If-Match": {etag}}); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("PATCH with a stale ETag: status %d, want 412", rec.Code)
+	}
+}
+
+// TestDisabledEndpointViews checks that disabling an entity answers 501 for
+// the views of its records too.
+func TestDisabledEndpointViews(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.DisabledEndpoints = []string{"classes", "orgs"}
+	s := newTestServer(cfg)
+	class, org := s.store.Classes[0].SourcedId, s.store.Orgs[0].SourcedId
+	for _, path := range []string{"/classes/" + class + "/capacity", "/classes/" + class + "/metadata", "/orgs/" + org + "/descendants", "/schools"} {
+		if rec := s.get(t, path); rec.Code != http.StatusNotImplemented {
+			t.Errorf("GET %s: %d, want 501", path, rec.Code)
+		}
+	}
+	if rec := s.get(t, "/classes/"+class+"/students"); rec.Code != http.StatusOK {
+		t.Errorf("GET /classes/{id}/students: %d, want 200", rec.Code)
+	}
+}
+
+// TestSimulatedClock checks that NewRouter starts the simulated clock that
+// cfg asks for.
+func TestSimulatedClock(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.SimulatedClock = true
+	s := newTestServer(cfg)
+	start := s.store.now()
+	rec := s.do(t, http.MethodPost, "/admin/clock/advance", `{"duration": "1h"}`, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("advancing the clock: %d %s", rec.Code, rec.Body)
+	}
+	if got := s.store.now().Sub(start); got != time.Hour {
+		t.Errorf("clock moved by %s, want 1h", got)
+	}
+}
+
+// TestWriteVisibilityDelay checks that NewRouter applies the write
+// visibility delay cfg asks for: a patched user is hidden from reads until
+// the delay has passed on the store's clock.
+func TestWriteVisibilityDelay(t *testing.T) {
+	cfg := testConfig(7)
+	cfg.SimulatedClock = true
+	cfg.WriteVisibilityDelay = time.Minute
+	s := newTestServer(cfg)
+	path := "/users/" + s.store.Users[0].SourcedId
+	if rec := s.do(t, http.MethodPatch, path, `{"user": {"givenName": "Changed"}}`, nil); rec.Code != http.StatusOK {
+		t.Fatalf("PATCH %s: %d %s", path, rec.Code, rec.Body)
+	}
+	if rec := s.get(t, path); rec.Code != http.StatusNotFound {
+		t.Errorf("GET %s within the delay: %d, want 404", path, rec.Code)
+	}
+	s.do(t, http.MethodPost, "/admin/clock/advance", `{"duration": "1m"}`, nil)
+	if rec := s.get(t, path); rec.Code != http.StatusOK {
+		t.Errorf("GET %s after the delay: %d, want 200", path, rec.Code)
+	}
+}
+
+// TestSpecRoute checks that /openapi.json serves the generated Swagger 2.0
+// document without authentication.
+func TestSpecRoute(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json: %d %s", rec.Code, rec.Body)
+	}
+	spec := decode[map[string]any](t, rec)
+	if spec["swagger"] != "2.0" {
+		t.Errorf("GET /openapi.json: swagger %v, want 2.0", spec["swagger"])
+	}
+	if paths, _ := spec["paths"].(map[string]any); paths["/users/{id}"] == nil {
+		t.Error("GET /openapi.json: no /users/{id} path")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// entityTypes pairs each envelope with the struct type written under it.
+var entityTypes = []struct {
+	envelope envelope
+	typ      reflect.Type
+}{
+	{orgEnvelope, reflect.TypeFor[Org]()},
+	{userEnvelope, reflect.TypeFor[User]()},
+	{courseEnvelope, reflect.TypeFor[Course]()},
+	{classEnvelope, reflect.TypeFor[Class]()},
+	{enrollmentEnvelope, reflect.TypeFor[Enrollment]()},
+	{academicSessionEnvelope, reflect.TypeFor[AcademicSession]()},
+	{categoryEnvelope, reflect.TypeFor[Category]()},
+	{lineItemEnvelope, reflect.TypeFor[LineItem]()},
+	{resultEnvelope, reflect.TypeFor[Result]()},
+	{resourceEnvelope, reflect.TypeFor[Resource]()},
+}
+
+// EntitySchema describes the JSON shape of one entity type.
+type EntitySchema struct {
+	Collection string        `json:"collection"` // the plural envelope key, e.g. "users"
+	Fields     []SchemaField `json:"fields"`
+}
+
+// SchemaField describes one serialized field of an entity.
+type SchemaField struct {
+	Name    string `json:"name"`    // the Go field name
+	JSONKey string `json:"jsonKey"` // the key it is written under
+	GoType  string `json:"goType"`
+	// Reference is set on GUIDRef fields, which point at another entity.
+	Reference bool `json:"reference"`
+	Array     bool `json:"array"`
+	// Required is set on fields that are always written with a value: not
+	// omitempty, a pointer or an interface, all of which may be absent or
+	// null.
+	Required bool `json:"required"`
+}
+
+// getSchema handles requests for the shape of every entity type, keyed by
+// its singular envelope key. It is derived from the struct types by
+// reflection, so it can't drift from what the API writes.
+func (h *APIHandlers) getSchema(w http.ResponseWriter, r *http.Request) {
+	schema := make(map[string]EntitySchema, len(entityTypes))
+	for _, entity := range entityTypes {
+		schema[entity.envelope.singular] = EntitySchema{Collection: entity.envelope.plural, Fields: schemaFields(entity.typ)}
+	}
+	writeJSON(w, http.StatusOK, schema)
+}
+
+// schemaFields describes the serialized fields of struct type t in order,
+// including those promoted from embedded structs such as BaseModel.
+func schemaFields(t reflect.Type) []SchemaField {
+	guidRef := reflect.TypeFor[GUIDRef]()
+	var fields []SchemaField
+	for _, sf := range reflect.VisibleFields(t) {
+		if sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+		key, options, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = sf.Name
+		}
+		kind := sf.Type.Kind()
+		fields = append(fields, SchemaField{
+			Name:      sf.Name,
+			JSONKey:   key,
+			GoType:    strings.ReplaceAll(sf.Type.String(), "main.", ""),
+			Reference: elemType(sf.Type) == guidRef,
+			Array:     kind == reflect.Slice,
+			Required:  !strings.Contains(options, "omitempty") && kind != reflect.Pointer && kind != reflect.Interface,
+		})
+	}
+	return fields
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// snakeCaseKeys returns middleware that rewrites the keys of every JSON
+// response to snake_case, so sourcedId becomes sourced_id and
+// dateLastModified date_last_modified, emulating a provider that ignores the
+// spec's camelCase. Values, including the metadata of an entity, are left
+// alone apart from their keys. The response is buffered until the handler
+// returns; bodies that aren't JSON pass through unchanged.
+func snakeCaseKeys(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &snakeCaseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if !sw.started {
+			// Leave the response to outer middleware, such as requestTimeout.
+			return
+		}
+		body := sw.body.Bytes()
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			if converted, err := snakeCaseJSON(body); err == nil {
+				body = converted
+			}
+		}
+		w.WriteHeader(sw.status)
+		w.Write(body)
+	})
+}
+
+// snakeCaseWriter holds back the status and body of a response so
+// snakeCaseKeys can rewrite the body before sending it.
+type snakeCaseWriter struct {
+	http.ResponseWriter
+	status  int
+	body    bytes.Buffer
+	started bool
+}
+
+func (sw *snakeCaseWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.started = true
+}
+
+func (sw *snakeCaseWriter) Write(b []byte) (int, error) {
+	sw.started = true
+	return sw.body.Write(b)
+}
+
+// snakeCaseJSON re-encodes the JSON values in data with every object key
+// converted by snakeCase, keeping the order of keys.
+func snakeCaseJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out bytes.Buffer
+	// Each open object or array counts the tokens written into it; in an
+	// object, keys are the even ones.
+	type container struct {
+		object bool
+		n      int
+	}
+	var stack []container
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteRune(rune(delim))
+			if len(stack) == 0 {
+				out.WriteByte('\n')
+			}
+			continue
+		}
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.n > 0 && (!top.object || top.n%2 == 0) {
+				out.WriteByte(',')
+			}
+			isKey := top.object && top.n%2 == 0
+			top.n++
+			if isKey {
+				key, _ := json.Marshal(snakeCase(tok.(string)))
+				out.Write(key)
+				out.WriteByte(':')
+				continue
+			}
+		}
+		switch tok := tok.(type) {
+		case json.Delim:
+			out.WriteRune(rune(tok))
+			stack = append(stack, container{object: tok == '{'})
+			continue
+		case json.Number:
+			out.WriteString(tok.String())
+		default:
+			value, _ := json.Marshal(tok)
+			out.Write(value)
+		}
+		if len(stack) == 0 {
+			out.WriteByte('\n')
+		}
+	}
+}
+
+// snakeCase converts a camelCase name to snake_case. An underscore goes
+// before each upper-case letter that starts a word, so userIds becomes
+// user_ids and imsx_CodeMinor imsx_code_minor.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// LoadDataStore reads a DataStore serialized as JSON, in the format written
+// by GET /admin/export/json, and checks that all its references resolve.
+// Dangling references are reported together in the returned error, except
+// on the records chaos mode left dangling on purpose; the remaining checks
+// of ValidateStore are left to the caller.
+func LoadDataStore(path string) (*DataStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ds := &DataStore{}
+	if err := json.Unmarshal(data, ds); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	enrollments := ds.enrollments()
+	dangling, _ := referenceErrors(ds, enrollments)
+	if errs, _ := partitionDeliberate(dangling, deliberateRecords(ds, enrollments)); len(errs) > 0 {
+		return nil, fmt.Errorf("%s has %d dangling reference(s):\n%w", path, len(errs), errors.Join(errs...))
+	}
+	ds.rebuildResultIndexes()
+	return ds, nil
+}
+
+// exportJSON handles requests for a dump of the whole store, in the format
+// LoadDataStore reads. Records hidden by a write visibility delay are
+// included. It lives outside the OneRoster base path, so it is not part of
+// the Swagger document.
+func (h *APIHandlers) exportJSON(w http.ResponseWriter, r *http.Request) {
+	h.Store.enrollments()
+	writeJSON(w, http.StatusOK, h.Store)
+}
+
+// getHighwater handles requests for the newest dateLastModified of each
+// collection, keyed by its JSON name, so a scheduler can tell whether a
+// delta sync is due without fetching the records. Only records visible to
+// reads count; an empty collection is left out.
+func (h *APIHandlers) getHighwater(w http.ResponseWriter, r *http.Request) {
+	ds := h.Store
+	highwater := make(map[string]time.Time)
+	newest(highwater, "orgs", visibleOnly(ds, ds.Orgs))
+	newest(highwater, "users", visibleOnly(ds, ds.Users))
+	newest(highwater, "courses", visibleOnly(ds, ds.Courses))
+	newest(highwater, "classes", visibleOnly(ds, ds.Classes))
+	newest(highwater, "enrollments", visibleOnly(ds, ds.enrollments()))
+	newest(highwater, "academicSessions", visibleOnly(ds, ds.AcademicSessions))
+	newest(highwater, "categories", visibleOnly(ds, ds.Categories))
+	newest(highwater, "lineItems", visibleOnly(ds, ds.LineItems))
+	newest(highwater, "results", visibleOnly(ds, ds.Results))
+	newest(highwater, "resources", visibleOnly(ds, ds.Resources))
+	writeJSON(w, http.StatusOK, highwater)
+}
+
+// newest records the latest modification time among items under key.
+func newest[T entity](highwater map[string]time.Time, key string, items []T) {
+	for _, item := range items {
+		if modified := item.modified(); modified.After(highwater[key]) {
+			highwater[key] = modified
+		}
+	}
+}
+
+// maxSnapshots caps how many snapshots are retained; taking another one
+// discards the oldest.
+const maxSnapshots = 10
+
+// snapshotStore holds in-memory snapshots of the collections, serialized as
+// JSON so that each one is a deep copy that later writes cannot reach.
+type snapshotStore struct {
+	mu    sync.Mutex
+	ids   []string // oldest first
+	saved map[string][]byte
+}
+
+// SnapshotResponse is the body returned by POST /admin/snapshot.
+type SnapshotResponse struct {
+	SnapshotId string `json:"snapshotId"`
+}
+
+// snapshot records a deep copy of the collections and returns its id.
+func (ds *DataStore) snapshot() (string, error) {
+	ds.mu.RLock()
+	ds.enrollments()
+	data, err := json.Marshal(ds)
+	ds.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+
+	s := &ds.snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saved == nil {
+		s.saved = make(map[string][]byte)
+	}
+	id := uuid.New().String()
+	s.ids = append(s.ids, id)
+	s.saved[id] = data
+	for len(s.ids) > maxSnapshots {
+		delete(s.saved, s.ids[0])
+		s.ids = s.ids[1:]
+	}
+	return id, nil
+}
+
+// restore replaces the collections with the snapshot taken under id. It
+// reports false if no such snapshot is retained. The snapshot itself is kept,
+// so it can be restored again.
+func (ds *DataStore) restore(id string) (bool, error) {
+	ds.snapshots.mu.Lock()
+	data, ok := ds.snapshots.saved[id]
+	ds.snapshots.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	saved := &DataStore{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return false, err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	// Taking the snapshot built any pending enrollments, so none are left
+	// to override the restored ones.
+	ds.Orgs = saved.Orgs
+	ds.Users = saved.Users
+	ds.Courses = saved.Courses
+	ds.Classes = saved.Classes
+	ds.Enrollments = saved.Enrollments
+	ds.AcademicSessions = saved.AcademicSessions
+	ds.Categories = saved.Categories
+	ds.LineItems = saved.LineItems
+	ds.Results = saved.Results
+	ds.Resources = saved.Resources
+	ds.rebuildResultIndexes()
+
+	// Writes still pending visibility were undone along with everything else.
+	ds.visibility.mu.Lock()
+	clear(ds.visibility.visibleAt)
+	ds.visibility.mu.Unlock()
+	return true, nil
+}
+
+// postSnapshot handles requests to snapshot the store, returning the id to
+// pass to POST /admin/restore/{id}. Only the newest maxSnapshots snapshots
+// are retained.
+func (h *APIHandlers) postSnapshot(w http.ResponseWriter, r *http.Request) {
+	id, err := h.Store.snapshot()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to take snapshot: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, SnapshotResponse{SnapshotId: id})
+}
+
+// postRestore handles requests to restore the snapshot with the given id.
+func (h *APIHandlers) postRestore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	ok, err := h.Store.restore(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to restore snapshot: "+err.Error())
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %q not found; only the newest %d snapshots are retained", id, maxSnapshots))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExportLoadRoundTrip checks that what GET /admin/export/json writes
+// loads back through LoadDataStore, chaos records included.
+func TestExportLoadRoundTrip(t *testing.T) {
+	for _, chaos := range []bool{false, true} {
+		cfg := testConfig(7)
+		cfg.Chaos = chaos
+		s := newTestServer(cfg)
+		rec := s.get(t, "/admin/export/json")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("chaos %v: export: %d %s", chaos, rec.Code, rec.Body)
+		}
+		path := filepath.Join(t.TempDir(), "export.json")
+		if err := os.WriteFile(path, rec.Body.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadDataStore(path)
+		if err != nil {
+			t.Fatalf("chaos %v: %v", chaos, err)
+		}
+		if len(loaded.Users) != len(s.store.Users) || len(loaded.Enrollments) != len(s.store.enrollments()) || len(loaded.Results) != len(s.store.Results) {
+			t.Errorf("chaos %v: loaded %d users, %d enrollments, %d results; exported %d, %d, %d", chaos,
+				len(loaded.Users), len(loaded.Enrollments), len(loaded.Results), len(s.store.Users), len(s.store.enrollments()), len(s.store.Results))
+		}
+		if violations, _ := checkStore(loaded); len(violations) > 0 {
+			t.Errorf("chaos %v: loaded store: %d violations, first %v", chaos, len(violations), violations[0])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// visibilityTracker emulates a provider with eventual consistency: records
+// written while a delay is configured stay hidden from reads until the delay
+// has elapsed. With no delay configured every record is visible immediately.
+type visibilityTracker struct {
+	mu        sync.Mutex
+	delay     time.Duration
+	visibleAt map[string]time.Time
+}
+
+// SetWriteVisibilityDelay configures how long newly created or updated records
+// stay invisible to reads. A zero delay restores immediate visibility.
+func (ds *DataStore) SetWriteVisibilityDelay(delay time.Duration) {
+	ds.visibility.mu.Lock()
+	defer ds.visibility.mu.Unlock()
+	ds.visibility.delay = delay
+}
+
+// markWritten records that the object with the given SourcedId was just
+// written, in the change log and for the visibility delay. Write paths must
+// call it after every create or update.
+func (ds *DataStore) markWritten(id string) {
+	ds.changes.record(id, ds.now())
+	ds.visibility.mu.Lock()
+	defer ds.visibility.mu.Unlock()
+	if ds.visibility.delay <= 0 {
+		return
+	}
+	if ds.visibility.visibleAt == nil {
+		ds.visibility.visibleAt = make(map[string]time.Time)
+	}
+	ds.visibility.visibleAt[id] = ds.now().Add(ds.visibility.delay)
+}
+
+// visibleOnly returns the items that reads may currently see. When no write
+// is pending visibility it returns items unchanged without copying.
+func visibleOnly[T entity](ds *DataStore, items []T) []T {
+	ds.visibility.mu.Lock()
+	defer ds.visibility.mu.Unlock()
+	if len(ds.visibility.visibleAt) == 0 {
+		return items
+	}
+	now := ds.now()
+	for id, at := range ds.visibility.visibleAt {
+		if !now.Before(at) {
+			delete(ds.visibility.visibleAt, id)
+		}
+	}
+	var visible []T
+	for _, item := range items {
+		if _, pending := ds.visibility.visibleAt[item.sourcedID()]; !pending {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
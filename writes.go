@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// enrollmentRolesByClassType is the enrollment role matrix enforced on
+// enrollment writes: the roles a user may be enrolled with in a class of
+// each type. Homerooms are pastoral, so an administrator may join one but
+// not a scheduled class, while proctors only supervise scheduled classes.
+var enrollmentRolesByClassType = map[string][]string{
+	"homeroom":  {"administrator", "student", "teacher"},
+	"scheduled": {"proctor", "student", "teacher"},
+}
+
+// EnrollmentRequest is the body accepted by PUT /enrollments/{id}.
+// @Description An enrollment to create or replace.
+type EnrollmentRequest struct {
+	Enrollment Enrollment `json:"enrollment"`
+}
+
+// putEnrollment handles creation or replacement of a single enrollment. An
+// If-Match header makes the replacement conditional; see checkIfMatch.
+// @Summary Create or replace an enrollment
+// @Description Stores the enrollment under the sourcedId in the path. The user and class must exist, and the role must be permitted for the class's type: homeroom classes accept administrator, student and teacher; scheduled classes accept proctor, student and teacher.
+// @Tags Enrollments
+// @Accept json
+// @Produce json
+// @Param id path string true "SourcedId of the enrollment"
+// @Param request body EnrollmentRequest true "Enrollment to write"
+// @Param If-Match header string false "ETag of the enrollment as last read; the write fails with 412 if it has changed since"
+// @Success 200 {object} map[string]Enrollment
+// @Success 201 {object} map[string]Enrollment
+// @Failure 400 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 428 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /enrollments/{id} [put]
+func (h *APIHandlers) putEnrollment(w http.ResponseWriter, r *http.Request) {
+	var req EnrollmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	enrollment := req.Enrollment
+	id := chi.URLParam(r, "id")
+	if enrollment.SourcedId != "" && enrollment.SourcedId != id {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Body sourcedId %q does not match the path", enrollment.SourcedId))
+		return
+	}
+	enrollment.SourcedId = id
+
+	ds := h.Store
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	existing := slices.IndexFunc(ds.enrollments(), func(e Enrollment) bool { return e.SourcedId == id })
+	current := ""
+	if existing >= 0 {
+		current = entityTag(ds.Enrollments[existing])
+	}
+	if !checkIfMatch(w, r, current, h.RequireIfMatch) {
+		return
+	}
+
+	if enrollment.Status == "" {
+		enrollment.Status = "active"
+	} else if !slices.Contains(statuses, enrollment.status()) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status %q", enrollment.Status))
+		return
+	}
+	if !slices.ContainsFunc(ds.Users, func(u User) bool { return u.SourcedId == enrollment.User.SourcedId }) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown user %q", enrollment.User.SourcedId))
+		return
+	}
+	c := slices.IndexFunc(ds.Classes, func(c Class) bool { return c.SourcedId == enrollment.Class.SourcedId })
+	if c < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Unknown class %q", enrollment.Class.SourcedId))
+		return
+	}
+	class := ds.Classes[c]
+	if allowed := enrollmentRolesByClassType[class.ClassType]; !slices.Contains(allowed, enrollment.Role) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Role %q is not permitted in %s class %s (permitted: %s)",
+			enrollment.Role, class.ClassType, class.SourcedId, strings.Join(allowed, ", ")))
+		return
+	}
+	enrollment.User = GUIDRef{Href: "/users/" + enrollment.User.SourcedId, SourcedId: enrollment.User.SourcedId, Type: "user"}
+	enrollment.Class = GUIDRef{Href: "/classes/" + class.SourcedId, SourcedId: class.SourcedId, Type: "class"}
+	enrollment.School = class.School
+	enrollment.DateLastModified = ds.now()
+
+	status := http.StatusCreated
+	if existing >= 0 {
+		ds.Enrollments[existing] = enrollment
+		status = http.StatusOK
+	} else {
+		ds.Enrollments = append(ds.Enrollments, enrollment)
+	}
+	ds.markWritten(id)
+	w.Header().Set("ETag", entityTag(enrollment))
+	writeJSON(w, status, map[string]Enrollment{enrollmentEnvelope.singular: enrollment})
+}
+
+// UserPatchRequest is the body accepted by PATCH /users/{id}.
+// @Description A partial user: only the fields present are changed.
+type UserPatchRequest struct {
+	User json.RawMessage `json:"user" swaggertype:"object"`
+}
+
+// patchUser handles partial updates of a single user. The user object in the
+// body is a JSON merge patch (RFC 7386): fields it omits keep their value, an
+// explicit null clears a field, and nested objects such as metadata are
+// merged the same way. The merged user is refused if it lost a required
+// field or holds a role outside userRoles. An If-Match header makes the
+// update conditional; see checkIfMatch.
+// @Summary Update part of a user
+// @Description Merges the fields present in the body into the user, as a JSON merge patch: omitted fields are left alone and null clears a field. dateLastModified is refreshed; sourcedId cannot be changed. The merged user must keep its required fields (username, givenName, familyName, role, orgs) and hold only OneRoster roles.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "SourcedId of the user"
+// @Param request body UserPatchRequest true "Fields of the user to change"
+// @Param If-Match header string false "ETag of the user as last read; the update fails with 412 if it has changed since"
+// @Success 200 {object} map[string]User
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 428 {object} map[string]string
+// @Security ApiKeyAuth
+// @Router /users/{id} [patch]
+func (h *APIHandlers) patchUser(w http.ResponseWriter, r *http.Request) {
+	var req UserPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	var patch map[string]any
+	if err := json.Unmarshal(req.User, &patch); err != nil || patch == nil {
+		writeError(w, http.StatusBadRequest, `Invalid request body: must be {"user": {...}} with the fields to change`)
+		return
+	}
+	id := chi.URLParam(r, "id")
+
+	ds := h.Store
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	i := slices.IndexFunc(ds.Users, func(u User) bool { return u.SourcedId == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !checkIfMatch(w, r, entityTag(ds.Users[i]), h.RequireIfMatch) {
+		return
+	}
+
+	var current map[string]any
+	data, _ := json.Marshal(ds.Users[i])
+	json.Unmarshal(data, &current)
+	data, _ = json.Marshal(mergePatch(current, patch))
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var user User
+	if err := dec.Decode(&user); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid user: "+err.Error())
+		return
+	}
+	if user.SourcedId != id {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Body sourcedId %q does not match the path", user.SourcedId))
+		return
+	}
+	if !slices.Contains(statuses, user.status()) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status %q", user.Status))
+		return
+	}
+	// Only problems the patch introduces are refused, so the users chaos
+	// mode leaves without orgs can still be patched.
+	before := userFieldErrors(ds.Users[i])
+	if problems := slices.DeleteFunc(userFieldErrors(user), func(p string) bool { return slices.Contains(before, p) }); len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "Invalid user: "+strings.Join(problems, "; "))
+		return
+	}
+	user.DateLastModified = ds.now()
+
+	ds.Users[i] = user
+	ds.markWritten(id)
+	w.Header().Set("ETag", entityTag(user))
+	writeJSON(w, http.StatusOK, map[string]User{userEnvelope.singular: user})
+}
+
+// mergePatch applies the JSON merge patch to target and returns the result:
+// null members of patch remove the member, objects are merged recursively
+// and any other value replaces the member.
+func mergePatch(target, patch any) any {
+	fields, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	merged, ok := target.(map[string]any)
+	if !ok {
+		merged = make(map[string]any)
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(merged, name)
+		} else {
+			merged[name] = mergePatch(merged[name], value)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestPatchUser checks that PATCH /users/{id} merges as RFC 7386 describes,
+// with null clearing a field and omitted fields kept, and refuses merged
+// users that are invalid.
+func TestPatchUser(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		patch string
+		want  int
+		check func(t *testing.T, before, after User)
+	}{
+		{"omitted fields kept", `{"user": {"givenName": "Changed"}}`, http.StatusOK, func(t *testing.T, before, after User) {
+			if after.GivenName != "Changed" || after.FamilyName != before.FamilyName || after.Email != before.Email {
+				t.Errorf("patched user %+v, want only givenName changed from %+v", after, before)
+			}
+		}},
+		{"null clears an optional field", `{"user": {"email": null}}`, http.StatusOK, func(t *testing.T, before, after User) {
+			if after.Email != "" || after.GivenName != before.GivenName {
+				t.Errorf("patched user %+v, want only email cleared", after)
+			}
+		}},
+		{"null on a required field", `{"user": {"givenName": null}}`, http.StatusBadRequest, nil},
+		{"null on orgs", `{"user": {"orgs": null}}`, http.StatusBadRequest, nil},
+		{"invalid role", `{"user": {"role": "wizard"}}`, http.StatusBadRequest, nil},
+		{"invalid extra role", `{"user": {"roles": ["teacher", "wizard"]}}`, http.StatusBadRequest, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestServer(testConfig(7))
+			before := s.store.Users[0]
+			rec := s.do(t, http.MethodPatch, "/users/"+before.SourcedId, tc.patch, nil)
+			if rec.Code != tc.want {
+				t.Fatalf("PATCH %s: %d %s, want %d", tc.patch, rec.Code, rec.Body, tc.want)
+			}
+			after := s.store.Users[0]
+			if tc.check != nil {
+				tc.check(t, before, after)
+			} else if entityTag(after) != entityTag(before) {
+				t.Errorf("refused PATCH %s changed the user", tc.patch)
+			}
+			if errs := ValidateStore(s.store); len(errs) > 0 {
+				t.Errorf("after PATCH %s: %d violations, first %v", tc.patch, len(errs), errs[0])
+			}
+		})
+	}
+}
+
+// TestPutEnrollment checks the role matrix of PUT /enrollments/{id} for each
+// class type, and its other reasons to refuse an enrollment.
+func TestPutEnrollment(t *testing.T) {
+	s := newTestServer(testConfig(7))
+	scheduled := s.store.Classes[0].SourcedId
+	s.store.Classes[1].ClassType = "homeroom"
+	homeroom := s.store.Classes[1].SourcedId
+	user := s.store.Users[0].SourcedId
+	body := func(class, role string) string {
+		return `{"enrollment": {"user": {"sourcedId": "` + user + `"}, "class": {"sourcedId": "` + class + `"}, "role": "` + role + `"}}`
+	}
+
+	for _, tc := range []struct {
+		class, role string
+		want        int
+	}{
+		{homeroom, "administrator", http.StatusCreated},
+		{homeroom, "student", http.StatusCreated},
+		{homeroom, "teacher", http.StatusCreated},
+		{homeroom, "proctor", http.StatusBadRequest},
+		{homeroom, "aide", http.StatusBadRequest},
+		{scheduled, "proctor", http.StatusCreated},
+		{scheduled, "student", http.StatusCreated},
+		{scheduled, "teacher", http.StatusCreated},
+		{scheduled, "administrator", http.StatusBadRequest},
+		{scheduled, "", http.StatusBadRequest},
+	} {
+		classType := map[string]string{homeroom: "homeroom", scheduled: "scheduled"}[tc.class]
+		path := "/enrollments/test-" + classType + "-" + tc.role
+		if rec := s.do(t, http.MethodPut, path, body(tc.class, tc.role), nil); rec.Code != tc.want {
+			t.Errorf("PUT %s role %q in a %s class: %d %s, want %d", path, tc.role, classType, rec.Code, rec.Body, tc.want)
+		}
+	}
+
+	path := "/enrollments/test-scheduled-student"
+	if rec := s.do(t, http.MethodPut, path, body(scheduled, "teacher"), nil); rec.Code != http.StatusOK {
+		t.Errorf("PUT %s replacing an enrollment: %d %s, want 200", path, rec.Code, rec.Body)
+	}
+	if got := decode[map[string]Enrollment](t, s.get(t, path))["enrollment"]; got.Role != "teacher" || got.School != s.store.Classes[0].School {
+		t.Errorf("GET %s: %+v, want the teacher enrollment in the class's school", path, got)
+	}
+
+	for name, request := range map[string]string{
+		"unknown user":    `{"enrollment": {"user": {"sourcedId": "nobody"}, "class": {"sourcedId": "` + scheduled + `"}, "role": "student"}}`,
+		"unknown class":   body("no-class", "student"),
+		"invalid status":  `{"enrollment": {"status": "gone", "user": {"sourcedId": "` + user + `"}, "class": {"sourcedId": "` + scheduled + `"}, "role": "student"}}`,
+		"other sourcedId": `{"enrollment": {"sourcedId": "other", "user": {"sourcedId": "` + user + `"}, "class": {"sourcedId": "` + scheduled + `"}, "role": "student"}}`,
+		"malformed body":  `{"enrollment": `,
+	} {
+		if rec := s.do(t, http.MethodPut, "/enrollments/test-refused", request, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("PUT with %s: %d %s, want 400", name, rec.Code, rec.Body)
+		}
+	}
+}